@@ -6,8 +6,11 @@ import (
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/internal/gh"
+	"github.com/cli/cli/v2/internal/ghinstance"
 	cmdCreate "github.com/cli/cli/v2/pkg/cmd/agent-task/create"
 	cmdList "github.com/cli/cli/v2/pkg/cmd/agent-task/list"
+	cmdStatus "github.com/cli/cli/v2/pkg/cmd/agent-task/status"
 	cmdView "github.com/cli/cli/v2/pkg/cmd/agent-task/view"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/go-gh/v2/pkg/auth"
@@ -16,6 +19,8 @@ import (
 
 // NewCmdAgentTask creates the base `agent-task` command.
 func NewCmdAgentTask(f *cmdutil.Factory) *cobra.Command {
+	var hostname string
+
 	cmd := &cobra.Command{
 		Use:     "agent-task <command>",
 		Aliases: []string{"agent-tasks", "agent", "agents"},
@@ -49,6 +54,24 @@ func NewCmdAgentTask(f *cmdutil.Factory) *cobra.Command {
 			$ gh agent-task view 12345abc-12345-12345-12345-12345abc
 		`),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if hostname != "" {
+				if auth.NormalizeHostname(hostname) != ghinstance.Default() {
+					return fmt.Errorf("agent tasks are not supported on this host: %s", hostname)
+				}
+
+				// Pin the host whose credentials are used for the OAuth check
+				// and for constructing the CAPI client, overriding the
+				// configured default host. This helps multi-account users
+				// whose default host is an Enterprise instance.
+				baseConfig := f.Config
+				f.Config = func() (gh.Config, error) {
+					cfg, err := baseConfig()
+					if err != nil {
+						return nil, err
+					}
+					return &hostOverrideConfig{Config: cfg, host: ghinstance.Default()}, nil
+				}
+			}
 			return requireOAuthToken(f)
 		},
 		// This is required to run this root command. We want to
@@ -58,14 +81,39 @@ func NewCmdAgentTask(f *cmdutil.Factory) *cobra.Command {
 		},
 	}
 
+	cmd.PersistentFlags().StringVar(&hostname, "hostname", "", "The GitHub hostname to authenticate against (only github.com is supported)")
+
 	// register subcommands
 	cmd.AddCommand(cmdList.NewCmdList(f, nil))
 	cmd.AddCommand(cmdCreate.NewCmdCreate(f, nil))
+	cmd.AddCommand(cmdStatus.NewCmdStatus(f, nil))
 	cmd.AddCommand(cmdView.NewCmdView(f, nil))
 
 	return cmd
 }
 
+// hostOverrideConfig wraps a config so that the default host resolves to the
+// host given via --hostname rather than the configured one. Everything that
+// derives a host from the config — the OAuth token check and the CAPI client
+// constructor — picks up the override without further plumbing.
+type hostOverrideConfig struct {
+	gh.Config
+	host string
+}
+
+func (c *hostOverrideConfig) Authentication() gh.AuthConfig {
+	return &hostOverrideAuthConfig{AuthConfig: c.Config.Authentication(), host: c.host}
+}
+
+type hostOverrideAuthConfig struct {
+	gh.AuthConfig
+	host string
+}
+
+func (c *hostOverrideAuthConfig) DefaultHost() (string, string) {
+	return c.host, "--hostname"
+}
+
 // requireOAuthToken ensures an OAuth (device flow) token is present and valid.
 // agent-task subcommands inherit this check via PersistentPreRunE.
 func requireOAuthToken(f *cmdutil.Factory) error {
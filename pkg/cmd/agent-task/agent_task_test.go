@@ -8,6 +8,7 @@ import (
 	ghmock "github.com/cli/cli/v2/internal/gh/mock"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/google/shlex"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,6 +36,7 @@ func setupMockOAuthConfig(t *testing.T, tokenSource string) gh.Config {
 func TestNewCmdAgentTask(t *testing.T) {
 	tests := []struct {
 		name            string
+		args            string
 		tokenSource     string
 		customConfig    func() (gh.Config, error)
 		wantErr         bool
@@ -108,6 +110,39 @@ func TestNewCmdAgentTask(t *testing.T) {
 			tokenSource: "",
 			wantErr:     true,
 		},
+		{
+			name:        "--hostname github.com is accepted",
+			args:        "--hostname github.com",
+			tokenSource: "oauth_token",
+			wantErr:     false,
+			wantStdout:  "",
+		},
+		{
+			name: "--hostname github.com overrides an enterprise default host",
+			args: "--hostname github.com",
+			customConfig: func() (gh.Config, error) {
+				// Without the flag this configuration is rejected, as the
+				// "enterprise host is rejected" case above verifies.
+				c := config.NewBlankConfig()
+				c.Set("github.com", "oauth_token", "gho_OAUTH123")
+				baseAuth := c.AuthenticationFunc
+				c.AuthenticationFunc = func() gh.AuthConfig {
+					a := baseAuth().(*config.AuthConfig)
+					a.SetDefaultHost("something.ghes.com", "GH_HOST")
+					return a
+				}
+				return c, nil
+			},
+			wantErr:    false,
+			wantStdout: "",
+		},
+		{
+			name:            "--hostname with enterprise host is rejected",
+			args:            "--hostname something.ghes.com",
+			tokenSource:     "oauth_token",
+			wantErr:         true,
+			wantErrContains: "agent tasks are not supported on this host: something.ghes.com",
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,7 +157,12 @@ func TestNewCmdAgentTask(t *testing.T) {
 			}
 
 			cmd := NewCmdAgentTask(f)
-			err := cmd.Execute()
+
+			argv, err := shlex.Split(tt.args)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+
+			err = cmd.Execute()
 
 			if tt.wantErr {
 				require.Error(t, err)
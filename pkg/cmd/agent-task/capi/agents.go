@@ -0,0 +1,70 @@
+package capi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Agent describes a coding agent available to the authenticated user.
+// Sessions reference agents by ID only; this is what maps an ID to a
+// human-readable name.
+type Agent struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListAgents enumerates the agents available to the authenticated user. The
+// result is cached on the client, so repeated calls within a single
+// invocation only hit the API once.
+func (c *CAPIClient) ListAgents(ctx context.Context) ([]*Agent, error) {
+	if c.agents != nil {
+		return c.agents, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/agents", http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list agents: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
+	}
+
+	var payload struct {
+		Agents []*Agent `json:"agents"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("failed to decode list agents response: %w", err)
+	}
+
+	// Cache a non-nil slice even when the list is empty so a second call
+	// doesn't refetch.
+	if payload.Agents == nil {
+		payload.Agents = []*Agent{}
+	}
+	c.agents = payload.Agents
+	return c.agents, nil
+}
+
+// AgentNamesByID fetches the available agents and returns an ID to name
+// mapping, for resolving a Session's AgentID into something displayable.
+func AgentNamesByID(ctx context.Context, client CapiClient) (map[int64]string, error) {
+	agents, err := client.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(agents))
+	for _, a := range agents {
+		names[a.ID] = a.Name
+	}
+	return names, nil
+}
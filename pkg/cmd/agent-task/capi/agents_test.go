@@ -0,0 +1,73 @@
+package capi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/internal/config"
+	"github.com/cli/cli/v2/pkg/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAgents(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(
+		httpmock.WithHost(httpmock.REST("GET", "agents"), "api.githubcopilot.com"),
+		httpmock.StatusStringResponse(200, heredoc.Doc(`
+			{
+				"agents": [
+					{
+						"id": 2,
+						"name": "Copilot coding agent",
+						"description": "Works on tasks in the background"
+					},
+					{
+						"id": 3,
+						"name": "Other agent"
+					}
+				]
+			}`,
+		)),
+	)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+	agents, err := capiClient.ListAgents(context.Background())
+	require.NoError(t, err)
+	require.Len(t, agents, 2)
+	assert.Equal(t, &Agent{ID: 2, Name: "Copilot coding agent", Description: "Works on tasks in the background"}, agents[0])
+	assert.Equal(t, &Agent{ID: 3, Name: "Other agent"}, agents[1])
+
+	// A second call is served from the per-invocation cache; the single
+	// registered stub would fail the request count otherwise.
+	again, err := capiClient.ListAgents(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, agents, again)
+	assert.Len(t, reg.Requests, 1)
+}
+
+func TestListAgentsError(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(
+		httpmock.WithHost(httpmock.REST("GET", "agents"), "api.githubcopilot.com"),
+		httpmock.StatusStringResponse(500, "boom"),
+	)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+	_, err := capiClient.ListAgents(context.Background())
+	assert.EqualError(t, err, "failed to list agents: 500 Internal Server Error: boom")
+}
@@ -2,8 +2,16 @@ package capi
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/cli/cli/v2/internal/build"
 	"github.com/cli/cli/v2/internal/gh"
 )
 
@@ -12,22 +20,133 @@ import (
 const baseCAPIURL = "https://api.githubcopilot.com"
 const capiHost = "api.githubcopilot.com"
 
+// capiAPIURLEnv is a development-only knob: it points the client at a staging
+// CAPI deployment without recompiling. The value must be an absolute https
+// URL; anything else is ignored and the production endpoint is used.
+const capiAPIURLEnv = "GH_COPILOT_API_URL"
+
+// capiBaseURL returns the base URL for CAPI requests, honoring the
+// development override when set and valid.
+func capiBaseURL() string {
+	if override := os.Getenv(capiAPIURLEnv); override != "" {
+		if u, err := url.Parse(override); err == nil && u.Scheme == "https" && u.Host != "" {
+			return strings.TrimSuffix(override, "/")
+		}
+	}
+	return baseCAPIURL
+}
+
+// apiVersionHeader pins the API version the client was written against, the
+// same way REST requests to GitHub do.
+const apiVersionHeader = "X-GitHub-Api-Version"
+const apiVersion = "2022-11-28"
+
 // CapiClient defines the methods used by the caller. Implementations
 // may be replaced with test doubles in unit tests.
 type CapiClient interface {
 	ListLatestSessionsForViewer(ctx context.Context, limit int) ([]*Session, error)
-	CreateJob(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*Job, error)
+	ListSessionsForOwner(ctx context.Context, owner string, limit int) ([]*Session, error)
+	ListSessionsForRepo(ctx context.Context, owner string, repo string, limit int) ([]*Session, error)
+	CreateJob(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*Job, error)
+	FollowUpJob(ctx context.Context, owner, repo, sessionID, problemStatement string) (*Job, error)
 	GetJob(ctx context.Context, owner, repo, jobID string) (*Job, error)
 	GetSession(ctx context.Context, id string) (*Session, error)
 	GetSessionLogs(ctx context.Context, id string) ([]byte, error)
+	GetSessionLogsFrom(ctx context.Context, id string, offset int64) ([]byte, bool, error)
+	GetSessionLogsReader(ctx context.Context, id string) (io.ReadCloser, error)
+	ListAgents(ctx context.Context) ([]*Agent, error)
 	ListSessionsByResourceID(ctx context.Context, resourceType string, resourceID int64, limit int) ([]*Session, error)
+	ListSessionsForPullRequest(ctx context.Context, hostname string, owner string, repo string, number int, limit int) ([]*Session, error)
 	GetPullRequestDatabaseID(ctx context.Context, hostname string, owner string, repo string, number int) (int64, string, error)
 }
 
 // CAPIClient is a client for interacting with the Copilot API
 type CAPIClient struct {
-	httpClient *http.Client
-	authCfg    gh.AuthConfig
+	httpClient      *http.Client
+	authCfg         gh.AuthConfig
+	baseURL         string
+	sessionsPerPage int
+	skipHydration   bool
+	minimalPRFields bool
+	eventType       string
+	trace           TraceFunc
+	defaultHeaders  map[string]string
+	idempotencyKey  string
+	// agents caches the ListAgents result for the lifetime of the client.
+	agents []*Agent
+}
+
+// TraceFunc receives one entry per request made through the client's
+// transport: the request method and URL, the response status code (zero when
+// the request failed before a response arrived), and how long the round trip
+// took. Request headers are deliberately not exposed so credentials cannot
+// end up in trace output.
+type TraceFunc func(method string, url string, statusCode int, duration time.Duration)
+
+// CAPIClientOption configures a CAPIClient created by NewCAPIClient.
+type CAPIClientOption func(*CAPIClient)
+
+// WithSessionsPerPage sets the page size used when listing sessions.
+func WithSessionsPerPage(n int) CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.sessionsPerPage = n
+	}
+}
+
+// WithEventType overrides the event type attributed to jobs created through
+// the client. Integrations embedding the CLI can use this for telemetry; the
+// CLI itself keeps the default.
+func WithEventType(eventType string) CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.eventType = eventType
+	}
+}
+
+// WithTrace registers a hook that is called once per request with the
+// request method, URL, response status, and timing.
+func WithTrace(trace TraceFunc) CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.trace = trace
+	}
+}
+
+// WithoutHydration disables the GraphQL hydration of pull request and user
+// data when fetching sessions, leaving those fields nil. This halves the API
+// calls when only the raw session data is needed.
+func WithoutHydration() CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.skipHydration = true
+	}
+}
+
+// WithDefaultHeader adds a header to every request made through the client,
+// unless the request already carries it. Integrations embedding the CLI can
+// use this to attach extra identification or routing headers.
+func WithDefaultHeader(name, value string) CAPIClientOption {
+	return func(c *CAPIClient) {
+		if c.defaultHeaders == nil {
+			c.defaultHeaders = map[string]string{}
+		}
+		c.defaultHeaders[name] = value
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key value sent when creating jobs,
+// replacing the per-call generated one. Integrations that manage their own
+// retries can use this to dedupe create requests across calls.
+func WithIdempotencyKey(key string) CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.idempotencyKey = key
+	}
+}
+
+// WithMinimalPRFields makes pull request hydration request only the fields
+// needed for summary displays, dropping large ones such as the body. This
+// keeps GraphQL payloads small when listing many sessions.
+func WithMinimalPRFields() CAPIClientOption {
+	return func(c *CAPIClient) {
+		c.minimalPRFields = true
+	}
 }
 
 // NewCAPIClient creates a new CAPI client. Provide a token and an HTTP client which
@@ -35,38 +154,155 @@ type CAPIClient struct {
 //
 // The provided HTTP client will be mutated for use with CAPI, so it should not
 // be reused elsewhere.
-func NewCAPIClient(httpClient *http.Client, authCfg gh.AuthConfig) *CAPIClient {
+func NewCAPIClient(httpClient *http.Client, authCfg gh.AuthConfig, opts ...CAPIClientOption) *CAPIClient {
 	host, _ := authCfg.DefaultHost()
 	token, _ := authCfg.ActiveToken(host)
 
-	httpClient.Transport = newCAPITransport(token, httpClient.Transport)
-	return &CAPIClient{
-		httpClient: httpClient,
-		authCfg:    authCfg,
+	client := &CAPIClient{
+		httpClient:      httpClient,
+		authCfg:         authCfg,
+		baseURL:         capiBaseURL(),
+		sessionsPerPage: defaultSessionsPerPage,
+		eventType:       defaultEventType,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	// Attach the integration ID header to whichever host the client targets,
+	// which is only the production one unless the development override is set.
+	apiHost := capiHost
+	if u, err := url.Parse(client.baseURL); err == nil && u.Host != "" {
+		apiHost = u.Host
+	}
+	httpClient.Transport = newCAPITransport(token, apiHost, client.trace, client.defaultHeaders, httpClient.Transport)
+	return client
+}
+
+// ErrCopilotNotEnabled reports that the authenticated user does not have
+// access to the Copilot coding agent.
+var ErrCopilotNotEnabled = errors.New("Copilot coding agent is not enabled for your account")
+
+// VerifyAgentAccess performs a cheap authenticated request against the agents
+// API to confirm that the Copilot coding agent is available to the
+// authenticated user. It returns ErrCopilotNotEnabled when the API rejects
+// the request in a way that indicates the feature is unavailable for the
+// account, rather than a transient failure.
+func (c *CAPIClient) VerifyAgentAccess(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/agents/sessions?page_number=1&page_size=1", http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
 	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return ErrCopilotNotEnabled
+	default:
+		return fmt.Errorf("failed to verify agent access: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
+	}
+}
+
+// errorBodyMaxLen caps how much of a response body is echoed in error
+// messages for non-success statuses.
+const errorBodyMaxLen = 512
+
+// readErrorBody reads at most errorBodyMaxLen bytes of a response body for
+// inclusion in an error message.
+func readErrorBody(r io.Reader) []byte {
+	body, _ := io.ReadAll(io.LimitReader(r, errorBodyMaxLen))
+	return body
+}
+
+// HTTPStatusError is an error that carries the HTTP status code of a failed
+// CAPI request, so callers that retry (such as job polling) can distinguish
+// transient server failures from permanent client errors.
+type HTTPStatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Message
+}
+
+// statusWithBody renders a status code plus a truncated response body for use
+// in error messages. For 4xx errors the body often carries the actionable
+// message. Only the server's response is echoed, never request headers, so
+// tokens cannot leak.
+func statusWithBody(statusCode int, body []byte) string {
+	status := fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode))
+	if len(body) > errorBodyMaxLen {
+		body = body[:errorBodyMaxLen]
+	}
+	if trimmed := strings.TrimSpace(string(body)); trimmed != "" {
+		return fmt.Sprintf("%s: %s", status, trimmed)
+	}
+	return status
 }
 
 // capiTransport adds the Copilot auth headers
 type capiTransport struct {
-	rp    http.RoundTripper
-	token string
+	rp      http.RoundTripper
+	token   string
+	host    string
+	trace   TraceFunc
+	headers map[string]string
 }
 
-func newCAPITransport(token string, rp http.RoundTripper) *capiTransport {
+func newCAPITransport(token, host string, trace TraceFunc, headers map[string]string, rp http.RoundTripper) *capiTransport {
 	return &capiTransport{
-		rp:    rp,
-		token: token,
+		rp:      rp,
+		token:   token,
+		host:    host,
+		trace:   trace,
+		headers: headers,
 	}
 }
 
 func (ct *capiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Authorization", "Bearer "+ct.token)
 
+	// The base transport provided by the standard gh HTTP client already
+	// identifies the CLI; only fill these in when it didn't.
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", "GitHub CLI "+build.Version)
+	}
+	if req.Header.Get(apiVersionHeader) == "" {
+		req.Header.Set(apiVersionHeader, apiVersion)
+	}
+	for name, value := range ct.headers {
+		if req.Header.Get(name) == "" {
+			req.Header.Set(name, value)
+		}
+	}
+
 	// Since this RoundTrip is reused for both Copilot API and
 	// GitHub API requests, we conditionally add the integration
 	// ID only when performing requests to the Copilot API.
-	if req.URL.Host == capiHost {
+	if req.URL.Host == ct.host {
 		req.Header.Add("Copilot-Integration-Id", "copilot-4-cli")
 	}
-	return ct.rp.RoundTrip(req)
+
+	if ct.trace == nil {
+		return ct.rp.RoundTrip(req)
+	}
+
+	start := time.Now()
+	res, err := ct.rp.RoundTrip(req)
+	statusCode := 0
+	if res != nil {
+		statusCode = res.StatusCode
+	}
+	// Only the method, URL, status, and timing are traced; request headers
+	// (which carry the token) never are.
+	ct.trace(req.Method, req.URL.String(), statusCode, time.Since(start))
+	return res, err
 }
@@ -5,6 +5,7 @@ package capi
 
 import (
 	"context"
+	"io"
 	"sync"
 )
 
@@ -18,9 +19,12 @@ var _ CapiClient = &CapiClientMock{}
 //
 //		// make and configure a mocked CapiClient
 //		mockedCapiClient := &CapiClientMock{
-//			CreateJobFunc: func(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string) (*Job, error) {
+//			CreateJobFunc: func(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*Job, error) {
 //				panic("mock out the CreateJob method")
 //			},
+//			FollowUpJobFunc: func(ctx context.Context, owner string, repo string, sessionID string, problemStatement string) (*Job, error) {
+//				panic("mock out the FollowUpJob method")
+//			},
 //			GetJobFunc: func(ctx context.Context, owner string, repo string, jobID string) (*Job, error) {
 //				panic("mock out the GetJob method")
 //			},
@@ -33,12 +37,30 @@ var _ CapiClient = &CapiClientMock{}
 //			GetSessionLogsFunc: func(ctx context.Context, id string) ([]byte, error) {
 //				panic("mock out the GetSessionLogs method")
 //			},
+//			GetSessionLogsFromFunc: func(ctx context.Context, id string, offset int64) ([]byte, bool, error) {
+//				panic("mock out the GetSessionLogsFrom method")
+//			},
+//			GetSessionLogsReaderFunc: func(ctx context.Context, id string) (io.ReadCloser, error) {
+//				panic("mock out the GetSessionLogsReader method")
+//			},
+//			ListAgentsFunc: func(ctx context.Context) ([]*Agent, error) {
+//				panic("mock out the ListAgents method")
+//			},
 //			ListLatestSessionsForViewerFunc: func(ctx context.Context, limit int) ([]*Session, error) {
 //				panic("mock out the ListLatestSessionsForViewer method")
 //			},
 //			ListSessionsByResourceIDFunc: func(ctx context.Context, resourceType string, resourceID int64, limit int) ([]*Session, error) {
 //				panic("mock out the ListSessionsByResourceID method")
 //			},
+//			ListSessionsForOwnerFunc: func(ctx context.Context, owner string, limit int) ([]*Session, error) {
+//				panic("mock out the ListSessionsForOwner method")
+//			},
+//			ListSessionsForPullRequestFunc: func(ctx context.Context, hostname string, owner string, repo string, number int, limit int) ([]*Session, error) {
+//				panic("mock out the ListSessionsForPullRequest method")
+//			},
+//			ListSessionsForRepoFunc: func(ctx context.Context, owner string, repo string, limit int) ([]*Session, error) {
+//				panic("mock out the ListSessionsForRepo method")
+//			},
 //		}
 //
 //		// use mockedCapiClient in code that requires CapiClient
@@ -47,7 +69,10 @@ var _ CapiClient = &CapiClientMock{}
 //	}
 type CapiClientMock struct {
 	// CreateJobFunc mocks the CreateJob method.
-	CreateJobFunc func(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string) (*Job, error)
+	CreateJobFunc func(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*Job, error)
+
+	// FollowUpJobFunc mocks the FollowUpJob method.
+	FollowUpJobFunc func(ctx context.Context, owner string, repo string, sessionID string, problemStatement string) (*Job, error)
 
 	// GetJobFunc mocks the GetJob method.
 	GetJobFunc func(ctx context.Context, owner string, repo string, jobID string) (*Job, error)
@@ -61,12 +86,30 @@ type CapiClientMock struct {
 	// GetSessionLogsFunc mocks the GetSessionLogs method.
 	GetSessionLogsFunc func(ctx context.Context, id string) ([]byte, error)
 
+	// GetSessionLogsFromFunc mocks the GetSessionLogsFrom method.
+	GetSessionLogsFromFunc func(ctx context.Context, id string, offset int64) ([]byte, bool, error)
+
+	// GetSessionLogsReaderFunc mocks the GetSessionLogsReader method.
+	GetSessionLogsReaderFunc func(ctx context.Context, id string) (io.ReadCloser, error)
+
+	// ListAgentsFunc mocks the ListAgents method.
+	ListAgentsFunc func(ctx context.Context) ([]*Agent, error)
+
 	// ListLatestSessionsForViewerFunc mocks the ListLatestSessionsForViewer method.
 	ListLatestSessionsForViewerFunc func(ctx context.Context, limit int) ([]*Session, error)
 
 	// ListSessionsByResourceIDFunc mocks the ListSessionsByResourceID method.
 	ListSessionsByResourceIDFunc func(ctx context.Context, resourceType string, resourceID int64, limit int) ([]*Session, error)
 
+	// ListSessionsForOwnerFunc mocks the ListSessionsForOwner method.
+	ListSessionsForOwnerFunc func(ctx context.Context, owner string, limit int) ([]*Session, error)
+
+	// ListSessionsForPullRequestFunc mocks the ListSessionsForPullRequest method.
+	ListSessionsForPullRequestFunc func(ctx context.Context, hostname string, owner string, repo string, number int, limit int) ([]*Session, error)
+
+	// ListSessionsForRepoFunc mocks the ListSessionsForRepo method.
+	ListSessionsForRepoFunc func(ctx context.Context, owner string, repo string, limit int) ([]*Session, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CreateJob holds details about calls to the CreateJob method.
@@ -81,6 +124,25 @@ type CapiClientMock struct {
 			ProblemStatement string
 			// BaseBranch is the baseBranch argument value.
 			BaseBranch string
+			// Draft is the draft argument value.
+			Draft *bool
+			// ContextRepos is the contextRepos argument value.
+			ContextRepos []string
+			// AgentID is the agentID argument value.
+			AgentID int64
+		}
+		// FollowUpJob holds details about calls to the FollowUpJob method.
+		FollowUpJob []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// SessionID is the sessionID argument value.
+			SessionID string
+			// ProblemStatement is the problemStatement argument value.
+			ProblemStatement string
 		}
 		// GetJob holds details about calls to the GetJob method.
 		GetJob []struct {
@@ -120,6 +182,27 @@ type CapiClientMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// GetSessionLogsFrom holds details about calls to the GetSessionLogsFrom method.
+		GetSessionLogsFrom []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+			// Offset is the offset argument value.
+			Offset int64
+		}
+		// GetSessionLogsReader holds details about calls to the GetSessionLogsReader method.
+		GetSessionLogsReader []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID string
+		}
+		// ListAgents holds details about calls to the ListAgents method.
+		ListAgents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// ListLatestSessionsForViewer holds details about calls to the ListLatestSessionsForViewer method.
 		ListLatestSessionsForViewer []struct {
 			// Ctx is the ctx argument value.
@@ -138,18 +221,60 @@ type CapiClientMock struct {
 			// Limit is the limit argument value.
 			Limit int
 		}
+		// ListSessionsForOwner holds details about calls to the ListSessionsForOwner method.
+		ListSessionsForOwner []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ListSessionsForPullRequest holds details about calls to the ListSessionsForPullRequest method.
+		ListSessionsForPullRequest []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Hostname is the hostname argument value.
+			Hostname string
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// Number is the number argument value.
+			Number int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// ListSessionsForRepo holds details about calls to the ListSessionsForRepo method.
+		ListSessionsForRepo []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+			// Repo is the repo argument value.
+			Repo string
+			// Limit is the limit argument value.
+			Limit int
+		}
 	}
 	lockCreateJob                   sync.RWMutex
+	lockFollowUpJob                 sync.RWMutex
 	lockGetJob                      sync.RWMutex
 	lockGetPullRequestDatabaseID    sync.RWMutex
 	lockGetSession                  sync.RWMutex
 	lockGetSessionLogs              sync.RWMutex
+	lockGetSessionLogsFrom          sync.RWMutex
+	lockGetSessionLogsReader        sync.RWMutex
+	lockListAgents                  sync.RWMutex
 	lockListLatestSessionsForViewer sync.RWMutex
 	lockListSessionsByResourceID    sync.RWMutex
+	lockListSessionsForOwner        sync.RWMutex
+	lockListSessionsForPullRequest  sync.RWMutex
+	lockListSessionsForRepo         sync.RWMutex
 }
 
 // CreateJob calls CreateJobFunc.
-func (mock *CapiClientMock) CreateJob(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string) (*Job, error) {
+func (mock *CapiClientMock) CreateJob(ctx context.Context, owner string, repo string, problemStatement string, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*Job, error) {
 	if mock.CreateJobFunc == nil {
 		panic("CapiClientMock.CreateJobFunc: method is nil but CapiClient.CreateJob was just called")
 	}
@@ -159,17 +284,23 @@ func (mock *CapiClientMock) CreateJob(ctx context.Context, owner string, repo st
 		Repo             string
 		ProblemStatement string
 		BaseBranch       string
+		Draft            *bool
+		ContextRepos     []string
+		AgentID          int64
 	}{
 		Ctx:              ctx,
 		Owner:            owner,
 		Repo:             repo,
 		ProblemStatement: problemStatement,
 		BaseBranch:       baseBranch,
+		Draft:            draft,
+		ContextRepos:     contextRepos,
+		AgentID:          agentID,
 	}
 	mock.lockCreateJob.Lock()
 	mock.calls.CreateJob = append(mock.calls.CreateJob, callInfo)
 	mock.lockCreateJob.Unlock()
-	return mock.CreateJobFunc(ctx, owner, repo, problemStatement, baseBranch)
+	return mock.CreateJobFunc(ctx, owner, repo, problemStatement, baseBranch, draft, contextRepos, agentID)
 }
 
 // CreateJobCalls gets all the calls that were made to CreateJob.
@@ -182,6 +313,9 @@ func (mock *CapiClientMock) CreateJobCalls() []struct {
 	Repo             string
 	ProblemStatement string
 	BaseBranch       string
+	Draft            *bool
+	ContextRepos     []string
+	AgentID          int64
 } {
 	var calls []struct {
 		Ctx              context.Context
@@ -189,6 +323,9 @@ func (mock *CapiClientMock) CreateJobCalls() []struct {
 		Repo             string
 		ProblemStatement string
 		BaseBranch       string
+		Draft            *bool
+		ContextRepos     []string
+		AgentID          int64
 	}
 	mock.lockCreateJob.RLock()
 	calls = mock.calls.CreateJob
@@ -196,6 +333,54 @@ func (mock *CapiClientMock) CreateJobCalls() []struct {
 	return calls
 }
 
+// FollowUpJob calls FollowUpJobFunc.
+func (mock *CapiClientMock) FollowUpJob(ctx context.Context, owner string, repo string, sessionID string, problemStatement string) (*Job, error) {
+	if mock.FollowUpJobFunc == nil {
+		panic("CapiClientMock.FollowUpJobFunc: method is nil but CapiClient.FollowUpJob was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		Owner            string
+		Repo             string
+		SessionID        string
+		ProblemStatement string
+	}{
+		Ctx:              ctx,
+		Owner:            owner,
+		Repo:             repo,
+		SessionID:        sessionID,
+		ProblemStatement: problemStatement,
+	}
+	mock.lockFollowUpJob.Lock()
+	mock.calls.FollowUpJob = append(mock.calls.FollowUpJob, callInfo)
+	mock.lockFollowUpJob.Unlock()
+	return mock.FollowUpJobFunc(ctx, owner, repo, sessionID, problemStatement)
+}
+
+// FollowUpJobCalls gets all the calls that were made to FollowUpJob.
+// Check the length with:
+//
+//	len(mockedCapiClient.FollowUpJobCalls())
+func (mock *CapiClientMock) FollowUpJobCalls() []struct {
+	Ctx              context.Context
+	Owner            string
+	Repo             string
+	SessionID        string
+	ProblemStatement string
+} {
+	var calls []struct {
+		Ctx              context.Context
+		Owner            string
+		Repo             string
+		SessionID        string
+		ProblemStatement string
+	}
+	mock.lockFollowUpJob.RLock()
+	calls = mock.calls.FollowUpJob
+	mock.lockFollowUpJob.RUnlock()
+	return calls
+}
+
 // GetJob calls GetJobFunc.
 func (mock *CapiClientMock) GetJob(ctx context.Context, owner string, repo string, jobID string) (*Job, error) {
 	if mock.GetJobFunc == nil {
@@ -360,6 +545,114 @@ func (mock *CapiClientMock) GetSessionLogsCalls() []struct {
 	return calls
 }
 
+// GetSessionLogsFrom calls GetSessionLogsFromFunc.
+func (mock *CapiClientMock) GetSessionLogsFrom(ctx context.Context, id string, offset int64) ([]byte, bool, error) {
+	if mock.GetSessionLogsFromFunc == nil {
+		panic("CapiClientMock.GetSessionLogsFromFunc: method is nil but CapiClient.GetSessionLogsFrom was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		ID     string
+		Offset int64
+	}{
+		Ctx:    ctx,
+		ID:     id,
+		Offset: offset,
+	}
+	mock.lockGetSessionLogsFrom.Lock()
+	mock.calls.GetSessionLogsFrom = append(mock.calls.GetSessionLogsFrom, callInfo)
+	mock.lockGetSessionLogsFrom.Unlock()
+	return mock.GetSessionLogsFromFunc(ctx, id, offset)
+}
+
+// GetSessionLogsFromCalls gets all the calls that were made to GetSessionLogsFrom.
+// Check the length with:
+//
+//	len(mockedCapiClient.GetSessionLogsFromCalls())
+func (mock *CapiClientMock) GetSessionLogsFromCalls() []struct {
+	Ctx    context.Context
+	ID     string
+	Offset int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		ID     string
+		Offset int64
+	}
+	mock.lockGetSessionLogsFrom.RLock()
+	calls = mock.calls.GetSessionLogsFrom
+	mock.lockGetSessionLogsFrom.RUnlock()
+	return calls
+}
+
+// GetSessionLogsReader calls GetSessionLogsReaderFunc.
+func (mock *CapiClientMock) GetSessionLogsReader(ctx context.Context, id string) (io.ReadCloser, error) {
+	if mock.GetSessionLogsReaderFunc == nil {
+		panic("CapiClientMock.GetSessionLogsReaderFunc: method is nil but CapiClient.GetSessionLogsReader was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  string
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetSessionLogsReader.Lock()
+	mock.calls.GetSessionLogsReader = append(mock.calls.GetSessionLogsReader, callInfo)
+	mock.lockGetSessionLogsReader.Unlock()
+	return mock.GetSessionLogsReaderFunc(ctx, id)
+}
+
+// GetSessionLogsReaderCalls gets all the calls that were made to GetSessionLogsReader.
+// Check the length with:
+//
+//	len(mockedCapiClient.GetSessionLogsReaderCalls())
+func (mock *CapiClientMock) GetSessionLogsReaderCalls() []struct {
+	Ctx context.Context
+	ID  string
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  string
+	}
+	mock.lockGetSessionLogsReader.RLock()
+	calls = mock.calls.GetSessionLogsReader
+	mock.lockGetSessionLogsReader.RUnlock()
+	return calls
+}
+
+// ListAgents calls ListAgentsFunc.
+func (mock *CapiClientMock) ListAgents(ctx context.Context) ([]*Agent, error) {
+	if mock.ListAgentsFunc == nil {
+		panic("CapiClientMock.ListAgentsFunc: method is nil but CapiClient.ListAgents was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockListAgents.Lock()
+	mock.calls.ListAgents = append(mock.calls.ListAgents, callInfo)
+	mock.lockListAgents.Unlock()
+	return mock.ListAgentsFunc(ctx)
+}
+
+// ListAgentsCalls gets all the calls that were made to ListAgents.
+// Check the length with:
+//
+//	len(mockedCapiClient.ListAgentsCalls())
+func (mock *CapiClientMock) ListAgentsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockListAgents.RLock()
+	calls = mock.calls.ListAgents
+	mock.lockListAgents.RUnlock()
+	return calls
+}
+
 // ListLatestSessionsForViewer calls ListLatestSessionsForViewerFunc.
 func (mock *CapiClientMock) ListLatestSessionsForViewer(ctx context.Context, limit int) ([]*Session, error) {
 	if mock.ListLatestSessionsForViewerFunc == nil {
@@ -439,3 +732,139 @@ func (mock *CapiClientMock) ListSessionsByResourceIDCalls() []struct {
 	mock.lockListSessionsByResourceID.RUnlock()
 	return calls
 }
+
+// ListSessionsForOwner calls ListSessionsForOwnerFunc.
+func (mock *CapiClientMock) ListSessionsForOwner(ctx context.Context, owner string, limit int) ([]*Session, error) {
+	if mock.ListSessionsForOwnerFunc == nil {
+		panic("CapiClientMock.ListSessionsForOwnerFunc: method is nil but CapiClient.ListSessionsForOwner was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Owner string
+		Limit int
+	}{
+		Ctx:   ctx,
+		Owner: owner,
+		Limit: limit,
+	}
+	mock.lockListSessionsForOwner.Lock()
+	mock.calls.ListSessionsForOwner = append(mock.calls.ListSessionsForOwner, callInfo)
+	mock.lockListSessionsForOwner.Unlock()
+	return mock.ListSessionsForOwnerFunc(ctx, owner, limit)
+}
+
+// ListSessionsForOwnerCalls gets all the calls that were made to ListSessionsForOwner.
+// Check the length with:
+//
+//	len(mockedCapiClient.ListSessionsForOwnerCalls())
+func (mock *CapiClientMock) ListSessionsForOwnerCalls() []struct {
+	Ctx   context.Context
+	Owner string
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Owner string
+		Limit int
+	}
+	mock.lockListSessionsForOwner.RLock()
+	calls = mock.calls.ListSessionsForOwner
+	mock.lockListSessionsForOwner.RUnlock()
+	return calls
+}
+
+// ListSessionsForPullRequest calls ListSessionsForPullRequestFunc.
+func (mock *CapiClientMock) ListSessionsForPullRequest(ctx context.Context, hostname string, owner string, repo string, number int, limit int) ([]*Session, error) {
+	if mock.ListSessionsForPullRequestFunc == nil {
+		panic("CapiClientMock.ListSessionsForPullRequestFunc: method is nil but CapiClient.ListSessionsForPullRequest was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Hostname string
+		Owner    string
+		Repo     string
+		Number   int
+		Limit    int
+	}{
+		Ctx:      ctx,
+		Hostname: hostname,
+		Owner:    owner,
+		Repo:     repo,
+		Number:   number,
+		Limit:    limit,
+	}
+	mock.lockListSessionsForPullRequest.Lock()
+	mock.calls.ListSessionsForPullRequest = append(mock.calls.ListSessionsForPullRequest, callInfo)
+	mock.lockListSessionsForPullRequest.Unlock()
+	return mock.ListSessionsForPullRequestFunc(ctx, hostname, owner, repo, number, limit)
+}
+
+// ListSessionsForPullRequestCalls gets all the calls that were made to ListSessionsForPullRequest.
+// Check the length with:
+//
+//	len(mockedCapiClient.ListSessionsForPullRequestCalls())
+func (mock *CapiClientMock) ListSessionsForPullRequestCalls() []struct {
+	Ctx      context.Context
+	Hostname string
+	Owner    string
+	Repo     string
+	Number   int
+	Limit    int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Hostname string
+		Owner    string
+		Repo     string
+		Number   int
+		Limit    int
+	}
+	mock.lockListSessionsForPullRequest.RLock()
+	calls = mock.calls.ListSessionsForPullRequest
+	mock.lockListSessionsForPullRequest.RUnlock()
+	return calls
+}
+
+// ListSessionsForRepo calls ListSessionsForRepoFunc.
+func (mock *CapiClientMock) ListSessionsForRepo(ctx context.Context, owner string, repo string, limit int) ([]*Session, error) {
+	if mock.ListSessionsForRepoFunc == nil {
+		panic("CapiClientMock.ListSessionsForRepoFunc: method is nil but CapiClient.ListSessionsForRepo was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Owner string
+		Repo  string
+		Limit int
+	}{
+		Ctx:   ctx,
+		Owner: owner,
+		Repo:  repo,
+		Limit: limit,
+	}
+	mock.lockListSessionsForRepo.Lock()
+	mock.calls.ListSessionsForRepo = append(mock.calls.ListSessionsForRepo, callInfo)
+	mock.lockListSessionsForRepo.Unlock()
+	return mock.ListSessionsForRepoFunc(ctx, owner, repo, limit)
+}
+
+// ListSessionsForRepoCalls gets all the calls that were made to ListSessionsForRepo.
+// Check the length with:
+//
+//	len(mockedCapiClient.ListSessionsForRepoCalls())
+func (mock *CapiClientMock) ListSessionsForRepoCalls() []struct {
+	Ctx   context.Context
+	Owner string
+	Repo  string
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Owner string
+		Repo  string
+		Limit int
+	}
+	mock.lockListSessionsForRepo.RLock()
+	calls = mock.calls.ListSessionsForRepo
+	mock.lockListSessionsForRepo.RUnlock()
+	return calls
+}
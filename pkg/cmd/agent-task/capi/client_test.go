@@ -0,0 +1,216 @@
+package capi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cli/cli/v2/internal/build"
+	"github.com/cli/cli/v2/internal/config"
+	"github.com/cli/cli/v2/pkg/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyAgentAccess(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    string
+		notEnabled bool
+	}{
+		{
+			name:       "access available",
+			statusCode: 200,
+		},
+		{
+			name:       "unauthorized",
+			statusCode: 401,
+			notEnabled: true,
+		},
+		{
+			name:       "forbidden",
+			statusCode: 403,
+			notEnabled: true,
+		},
+		{
+			name:       "endpoint not found",
+			statusCode: 404,
+			notEnabled: true,
+		},
+		{
+			name:       "server error",
+			statusCode: 500,
+			wantErr:    "failed to verify agent access: 500 Internal Server Error: {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			reg.Register(
+				httpmock.WithHost(
+					httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+						"page_number": {"1"},
+						"page_size":   {"1"},
+					}),
+					"api.githubcopilot.com",
+				),
+				httpmock.StatusStringResponse(tt.statusCode, `{}`),
+			)
+			defer reg.Verify(t)
+
+			httpClient := &http.Client{Transport: reg}
+
+			cfg := config.NewBlankConfig()
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+			err := capiClient.VerifyAgentAccess(context.Background())
+
+			if tt.notEnabled {
+				require.ErrorIs(t, err, ErrCopilotNotEnabled)
+				return
+			}
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDefaultRequestHeaders(t *testing.T) {
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"1"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StatusStringResponse(200, `{}`),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithDefaultHeader("X-Custom", "custom-value"))
+
+	require.NoError(t, capiClient.VerifyAgentAccess(context.Background()))
+
+	require.Len(t, reg.Requests, 1)
+	header := reg.Requests[0].Header
+	require.Equal(t, "GitHub CLI "+build.Version, header.Get("User-Agent"))
+	require.Equal(t, apiVersion, header.Get(apiVersionHeader))
+	require.Equal(t, "custom-value", header.Get("X-Custom"))
+	require.Equal(t, "copilot-4-cli", header.Get("Copilot-Integration-Id"))
+}
+
+func TestBaseURLEnvOverride(t *testing.T) {
+	t.Run("valid https override redirects requests", func(t *testing.T) {
+		t.Setenv(capiAPIURLEnv, "https://capi.staging.example.com/")
+
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(
+				httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+					"page_number": {"1"},
+					"page_size":   {"1"},
+				}),
+				"capi.staging.example.com",
+			),
+			httpmock.StatusStringResponse(200, `{}`),
+		)
+		defer reg.Verify(t)
+
+		httpClient := &http.Client{Transport: reg}
+
+		cfg := config.NewBlankConfig()
+		capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+		require.NoError(t, capiClient.VerifyAgentAccess(context.Background()))
+
+		require.Len(t, reg.Requests, 1)
+		require.Equal(t, "capi.staging.example.com", reg.Requests[0].URL.Host)
+		// The integration ID must follow the override host.
+		require.Equal(t, "copilot-4-cli", reg.Requests[0].Header.Get("Copilot-Integration-Id"))
+	})
+
+	t.Run("invalid override falls back to production", func(t *testing.T) {
+		t.Setenv(capiAPIURLEnv, "http://insecure.example.com")
+
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(
+				httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+					"page_number": {"1"},
+					"page_size":   {"1"},
+				}),
+				"api.githubcopilot.com",
+			),
+			httpmock.StatusStringResponse(200, `{}`),
+		)
+		defer reg.Verify(t)
+
+		httpClient := &http.Client{Transport: reg}
+
+		cfg := config.NewBlankConfig()
+		capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+		require.NoError(t, capiClient.VerifyAgentAccess(context.Background()))
+	})
+}
+
+func TestWithTraceInvokedPerRequest(t *testing.T) {
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"1"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StatusStringResponse(200, `{}`),
+	)
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf/logs"),
+			"api.githubcopilot.com",
+		),
+		httpmock.StatusStringResponse(404, `{}`),
+	)
+	defer reg.Verify(t)
+
+	type traceEntry struct {
+		method     string
+		url        string
+		statusCode int
+	}
+	var traced []traceEntry
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithTrace(func(method, url string, statusCode int, duration time.Duration) {
+		require.GreaterOrEqual(t, duration, time.Duration(0))
+		traced = append(traced, traceEntry{method: method, url: url, statusCode: statusCode})
+	}))
+
+	require.NoError(t, capiClient.VerifyAgentAccess(context.Background()))
+
+	_, err := capiClient.GetSessionLogs(context.Background(), "e2fa49d2-f164-4a56-ab99-498090b8fcdf")
+	require.ErrorIs(t, err, ErrSessionNotFound)
+
+	require.Len(t, traced, 2)
+	require.Equal(t, "GET", traced[0].method)
+	require.Contains(t, traced[0].url, "https://api.githubcopilot.com/agents/sessions?")
+	require.Equal(t, 200, traced[0].statusCode)
+	require.Equal(t, "GET", traced[1].method)
+	require.Equal(t, "https://api.githubcopilot.com/agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf/logs", traced[1].url)
+	require.Equal(t, 404, traced[1].statusCode)
+}
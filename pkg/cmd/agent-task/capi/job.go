@@ -3,9 +3,11 @@ package capi
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"time"
@@ -26,7 +28,14 @@ type Job struct {
 	CreatedAt         time.Time       `json:"created_at,omitempty"`
 	UpdatedAt         time.Time       `json:"updated_at,omitempty"`
 	PullRequest       *JobPullRequest `json:"pull_request,omitempty"`
-	WorkflowRun       *struct {
+	// ContextRepositories lists additional repositories the agent may need
+	// read access to while working on the task. A pass-through to the API;
+	// servers that don't support it ignore the key.
+	ContextRepositories []string `json:"context_repositories,omitempty"`
+	// AgentID selects which agent handles the job. Zero omits the key,
+	// leaving the choice to the server's default agent for the account.
+	AgentID     int64 `json:"agent_id,omitempty"`
+	WorkflowRun *struct {
 		ID string `json:"id"`
 	} `json:"workflow_run,omitempty"`
 	ErrorInfo *JobError `json:"error,omitempty"`
@@ -41,6 +50,10 @@ type JobPullRequest struct {
 	ID      int    `json:"id"`
 	Number  int    `json:"number"`
 	BaseRef string `json:"base_ref,omitempty"`
+	// Draft requests a draft state for the created pull request. It is a
+	// request, not a guarantee: servers that don't support it keep their
+	// default behavior. Nil leaves the state to the server.
+	Draft *bool `json:"draft,omitempty"`
 }
 
 type JobError struct {
@@ -49,12 +62,34 @@ type JobError struct {
 	Service            string `json:"service"`
 }
 
-const jobsBasePathV1 = baseCAPIURL + "/agents/swe/v1/jobs"
+const jobsPathV1 = "/agents/swe/v1/jobs"
+
+// jobsBasePathV1 is the fully qualified base URL of the v1 Jobs API, built
+// from the client's base URL so the development override applies.
+func (c *CAPIClient) jobsBasePathV1() string {
+	return c.baseURL + jobsPathV1
+}
+
+// idempotencyKeyHeader carries a key unique to one CreateJob call so that a
+// resubmitted create request cannot queue the same task twice.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey generates a random version 4 UUID for use as an
+// idempotency key.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
 
 // CreateJob queues a new job using the v1 Jobs API. It may or may not
 // return Pull Request information. If Pull Request information is required
 // following up by polling GetJob with the job ID is necessary.
-func (c *CAPIClient) CreateJob(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*Job, error) {
+func (c *CAPIClient) CreateJob(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*Job, error) {
 	if owner == "" || repo == "" {
 		return nil, errors.New("owner and repo are required")
 	}
@@ -62,17 +97,95 @@ func (c *CAPIClient) CreateJob(ctx context.Context, owner, repo, problemStatemen
 		return nil, errors.New("problem statement is required")
 	}
 
-	url := fmt.Sprintf("%s/%s/%s", jobsBasePathV1, url.PathEscape(owner), url.PathEscape(repo))
+	url := fmt.Sprintf("%s/%s/%s", c.jobsBasePathV1(), url.PathEscape(owner), url.PathEscape(repo))
 
-	prOpts := JobPullRequest{}
+	prOpts := JobPullRequest{Draft: draft}
 	if baseBranch != "" {
 		prOpts.BaseRef = "refs/heads/" + baseBranch
 	}
 
+	payload := &Job{
+		ProblemStatement:    problemStatement,
+		EventType:           c.eventType,
+		PullRequest:         &prOpts,
+		ContextRepositories: contextRepos,
+		AgentID:             agentID,
+	}
+
+	b, _ := json.Marshal(payload)
+
+	key := c.idempotencyKey
+	if key == "" {
+		var err error
+		if key, err = newIdempotencyKey(); err != nil {
+			return nil, err
+		}
+	}
+
+	var res *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(idempotencyKeyHeader, key)
+
+		res, err = c.httpClient.Do(req)
+		if err != nil {
+			// A POST is normally unsafe to resubmit, but the idempotency key
+			// lets the server dedupe it, so one retry after a transport-level
+			// failure cannot double-submit the task.
+			if attempt == 0 && ctx.Err() == nil {
+				continue
+			}
+			return nil, err
+		}
+		break
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK { // accept 201 or 200
+		var j Job
+		if err := json.Unmarshal(body, &j); err == nil && j.ErrorInfo != nil {
+			return nil, fmt.Errorf("failed to create job: %s", j.ErrorInfo.Message)
+		}
+		// This happens when there's an error like unauthorized (401).
+		return nil, fmt.Errorf("failed to create job: %s", statusWithBody(res.StatusCode, body))
+	}
+
+	var j Job
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&j); err != nil {
+		return nil, fmt.Errorf("failed to decode create job response: %w", err)
+	}
+
+	return &j, nil
+}
+
+// FollowUpJob queues a follow-up task on an existing agent session using the
+// v1 Jobs API, asking the agent to continue its work on the session's pull
+// request. The response has the same shape as CreateJob.
+func (c *CAPIClient) FollowUpJob(ctx context.Context, owner, repo, sessionID, problemStatement string) (*Job, error) {
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner and repo are required")
+	}
+	if sessionID == "" {
+		return nil, errors.New("session ID is required")
+	}
+	if problemStatement == "" {
+		return nil, errors.New("problem statement is required")
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/sessions/%s/follow_up", c.jobsBasePathV1(), url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(sessionID))
+
 	payload := &Job{
 		ProblemStatement: problemStatement,
-		EventType:        defaultEventType,
-		PullRequest:      &prOpts,
+		EventType:        c.eventType,
 	}
 
 	b, _ := json.Marshal(payload)
@@ -88,22 +201,22 @@ func (c *CAPIClient) CreateJob(ctx context.Context, owner, repo, problemStatemen
 	}
 	defer res.Body.Close()
 
-	var j Job
-	if err := json.NewDecoder(res.Body).Decode(&j); err != nil {
-		if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK { // accept 201 or 200
-			// This happens when there's an error like unauthorized (401).
-			statusText := fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
-			return nil, fmt.Errorf("failed to create job: %s", statusText)
-		}
-		return nil, fmt.Errorf("failed to decode create job response: %w", err)
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
 	}
 
 	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK { // accept 201 or 200
-		if j.ErrorInfo != nil {
-			return nil, fmt.Errorf("failed to create job: %s", j.ErrorInfo.Message)
+		var j Job
+		if err := json.Unmarshal(body, &j); err == nil && j.ErrorInfo != nil {
+			return nil, fmt.Errorf("failed to create follow-up job: %s", j.ErrorInfo.Message)
 		}
-		statusText := fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
-		return nil, fmt.Errorf("failed to create job: %s", statusText)
+		return nil, fmt.Errorf("failed to create follow-up job: %s", statusWithBody(res.StatusCode, body))
+	}
+
+	var j Job
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(&j); err != nil {
+		return nil, fmt.Errorf("failed to decode follow-up job response: %w", err)
 	}
 
 	return &j, nil
@@ -114,7 +227,7 @@ func (c *CAPIClient) GetJob(ctx context.Context, owner, repo, jobID string) (*Jo
 	if owner == "" || repo == "" || jobID == "" {
 		return nil, errors.New("owner, repo, and jobID are required")
 	}
-	url := fmt.Sprintf("%s/%s/%s/%s", jobsBasePathV1, url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(jobID))
+	url := fmt.Sprintf("%s/%s/%s/%s", c.jobsBasePathV1(), url.PathEscape(owner), url.PathEscape(repo), url.PathEscape(jobID))
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
 		return nil, err
@@ -125,9 +238,13 @@ func (c *CAPIClient) GetJob(ctx context.Context, owner, repo, jobID string) (*Jo
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		// Normalize to "<code> <text>" form
-		statusText := fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode))
-		return nil, fmt.Errorf("failed to get job: %s", statusText)
+		// Normalized to "<code> <text>" form, plus the response body when
+		// present. The status code is carried on the error so pollers can tell
+		// transient server failures from permanent client errors.
+		return nil, &HTTPStatusError{
+			StatusCode: res.StatusCode,
+			Message:    fmt.Sprintf("failed to get job: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body))),
+		}
 	}
 	var j Job
 	if err := json.NewDecoder(res.Body).Decode(&j); err != nil {
@@ -2,6 +2,7 @@ package capi
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"testing"
 	"time"
@@ -134,7 +135,7 @@ func TestGetJob(t *testing.T) {
 					httpmock.StatusStringResponse(404, `{}`),
 				)
 			},
-			wantErr: "failed to get job: 404 Not Found",
+			wantErr: "failed to get job: 404 Not Found: {}",
 		},
 		{
 			name: "API error",
@@ -144,7 +145,7 @@ func TestGetJob(t *testing.T) {
 					httpmock.StatusStringResponse(500, `{}`),
 				)
 			},
-			wantErr: "failed to get job: 500 Internal Server Error",
+			wantErr: "failed to get job: 500 Internal Server Error: {}",
 		},
 		{
 			name: "invalid JSON response",
@@ -188,14 +189,14 @@ func TestGetJob(t *testing.T) {
 func TestCreateJobRequiresRepoAndProblemStatement(t *testing.T) {
 	client := &CAPIClient{}
 
-	_, err := client.CreateJob(context.Background(), "", "only-repo", "", "")
+	_, err := client.CreateJob(context.Background(), "", "only-repo", "", "", nil, nil, 0)
 	assert.EqualError(t, err, "owner and repo are required")
-	_, err = client.CreateJob(context.Background(), "only-owner", "", "", "")
+	_, err = client.CreateJob(context.Background(), "only-owner", "", "", "", nil, nil, 0)
 	assert.EqualError(t, err, "owner and repo are required")
-	_, err = client.CreateJob(context.Background(), "", "", "", "")
+	_, err = client.CreateJob(context.Background(), "", "", "", "", nil, nil, 0)
 	assert.EqualError(t, err, "owner and repo are required")
 
-	_, err = client.CreateJob(context.Background(), "owner", "repo", "", "")
+	_, err = client.CreateJob(context.Background(), "owner", "repo", "", "", nil, nil, 0)
 	assert.EqualError(t, err, "problem statement is required")
 }
 
@@ -204,13 +205,55 @@ func TestCreateJob(t *testing.T) {
 	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
 	require.NoError(t, err)
 
+	draftRequest := true
+
 	tests := []struct {
-		name       string
-		baseBranch string
-		httpStubs  func(*testing.T, *httpmock.Registry)
-		wantErr    string
-		wantOut    *Job
+		name         string
+		baseBranch   string
+		draft        *bool
+		contextRepos []string
+		agentID      int64
+		httpStubs    func(*testing.T, *httpmock.Registry)
+		wantErr      string
+		wantOut      *Job
 	}{
+		{
+			name:    "success with agent ID",
+			agentID: 7,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+					httpmock.RESTPayload(201,
+						`{"job_id": "job123", "session_id": "sess1"}`,
+						func(payload map[string]interface{}) {
+							assert.Equal(t, float64(7), payload["agent_id"])
+						},
+					),
+				)
+			},
+			wantOut: &Job{
+				ID:        "job123",
+				SessionID: "sess1",
+			},
+		},
+		{
+			name: "zero agent ID omits the key",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+					httpmock.RESTPayload(201,
+						`{"job_id": "job123", "session_id": "sess1"}`,
+						func(payload map[string]interface{}) {
+							assert.NotContains(t, payload, "agent_id")
+						},
+					),
+				)
+			},
+			wantOut: &Job{
+				ID:        "job123",
+				SessionID: "sess1",
+			},
+		},
 		{
 			name: "success",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
@@ -319,6 +362,42 @@ func TestCreateJob(t *testing.T) {
 			},
 			wantErr: "failed to create job: some error",
 		},
+		{
+			name:  "success with draft request",
+			draft: &draftRequest,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+					httpmock.RESTPayload(201, `{"job_id": "job123"}`,
+						func(payload map[string]interface{}) {
+							pr, ok := payload["pull_request"].(map[string]interface{})
+							require.True(t, ok, "expected a pull_request object in the payload")
+							assert.Equal(t, true, pr["draft"])
+						},
+					),
+				)
+			},
+			wantOut: &Job{
+				ID: "job123",
+			},
+		},
+		{
+			name:         "success with context repositories",
+			contextRepos: []string{"OWNER/OTHER", "OWNER/THIRD"},
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+					httpmock.RESTPayload(201, `{"job_id": "job123"}`,
+						func(payload map[string]interface{}) {
+							assert.Equal(t, []interface{}{"OWNER/OTHER", "OWNER/THIRD"}, payload["context_repositories"])
+						},
+					),
+				)
+			},
+			wantOut: &Job{
+				ID: "job123",
+			},
+		},
 		{
 			name: "API error",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
@@ -327,7 +406,7 @@ func TestCreateJob(t *testing.T) {
 					httpmock.StatusStringResponse(500, `{}`),
 				)
 			},
-			wantErr: "failed to create job: 500 Internal Server Error",
+			wantErr: "failed to create job: 500 Internal Server Error: {}",
 		},
 		{
 			name: "invalid JSON response, non-HTTP 200",
@@ -337,7 +416,7 @@ func TestCreateJob(t *testing.T) {
 					httpmock.StatusStringResponse(401, `Unauthorized`),
 				)
 			},
-			wantErr: "failed to create job: 401 Unauthorized",
+			wantErr: "failed to create job: 401 Unauthorized: Unauthorized",
 		},
 		{
 			name: "invalid JSON response, HTTP 200",
@@ -364,7 +443,203 @@ func TestCreateJob(t *testing.T) {
 			cfg := config.NewBlankConfig()
 			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
 
-			job, err := capiClient.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", tt.baseBranch)
+			job, err := capiClient.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", tt.baseBranch, tt.draft, tt.contextRepos, tt.agentID)
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				require.Nil(t, job)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOut, job)
+		})
+	}
+}
+
+func TestCreateJobCustomEventType(t *testing.T) {
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+		httpmock.RESTPayload(201, `{"job_id": "job123"}`,
+			func(payload map[string]interface{}) {
+				assert.Equal(t, "my_integration", payload["event_type"])
+			},
+		),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithEventType("my_integration"))
+
+	job, err := capiClient.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", "", nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, "job123", job.ID)
+}
+
+func TestCreateJobIdempotencyKey(t *testing.T) {
+	t.Run("generated key is a UUID and survives a retried attempt", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		// The first attempt dies at the transport level; the retry must carry
+		// the same idempotency key so the server can dedupe both attempts.
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+			func(*http.Request) (*http.Response, error) {
+				return nil, errors.New("connection reset")
+			},
+		)
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(201, `{"job_id": "job123"}`),
+		)
+		defer reg.Verify(t)
+
+		httpClient := &http.Client{Transport: reg}
+
+		cfg := config.NewBlankConfig()
+		capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+		job, err := capiClient.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", "", nil, nil, 0)
+		require.NoError(t, err)
+		require.Equal(t, "job123", job.ID)
+
+		require.Len(t, reg.Requests, 2)
+		key := reg.Requests[0].Header.Get("Idempotency-Key")
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, key)
+		assert.Equal(t, key, reg.Requests[1].Header.Get("Idempotency-Key"))
+	})
+
+	t.Run("caller-provided key is sent as-is", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(201, `{"job_id": "job123"}`),
+		)
+		defer reg.Verify(t)
+
+		httpClient := &http.Client{Transport: reg}
+
+		cfg := config.NewBlankConfig()
+		capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithIdempotencyKey("my-key"))
+
+		_, err := capiClient.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", "", nil, nil, 0)
+		require.NoError(t, err)
+
+		require.Len(t, reg.Requests, 1)
+		assert.Equal(t, "my-key", reg.Requests[0].Header.Get("Idempotency-Key"))
+	})
+}
+
+func TestFollowUpJobRequiresArguments(t *testing.T) {
+	client := &CAPIClient{}
+
+	_, err := client.FollowUpJob(context.Background(), "", "only-repo", "sess1", "statement")
+	assert.EqualError(t, err, "owner and repo are required")
+	_, err = client.FollowUpJob(context.Background(), "only-owner", "", "sess1", "statement")
+	assert.EqualError(t, err, "owner and repo are required")
+
+	_, err = client.FollowUpJob(context.Background(), "owner", "repo", "", "statement")
+	assert.EqualError(t, err, "session ID is required")
+
+	_, err = client.FollowUpJob(context.Background(), "owner", "repo", "sess1", "")
+	assert.EqualError(t, err, "problem statement is required")
+}
+
+func TestFollowUpJob(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		httpStubs func(*testing.T, *httpmock.Registry)
+		wantErr   string
+		wantOut   *Job
+	}{
+		{
+			name: "success",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO/sessions/sess1/follow_up"), "api.githubcopilot.com"),
+					httpmock.RESTPayload(201,
+						heredoc.Docf(`
+							{
+								"job_id": "job456",
+								"session_id": "sess1",
+								"problem_statement": "Keep going",
+								"event_type": "foo",
+								"status": "foo",
+								"actor": {
+									"id": 1,
+									"login": "octocat"
+								},
+								"created_at": "%[1]s",
+								"updated_at": "%[1]s"
+							}
+						`, sampleDateString),
+						func(payload map[string]interface{}) {
+							assert.Equal(t, "Keep going", payload["problem_statement"])
+							assert.Equal(t, "gh_cli", payload["event_type"])
+						},
+					),
+				)
+			},
+			wantOut: &Job{
+				ID:               "job456",
+				SessionID:        "sess1",
+				ProblemStatement: "Keep going",
+				EventType:        "foo",
+				Status:           "foo",
+				Actor: &JobActor{
+					ID:    1,
+					Login: "octocat",
+				},
+				CreatedAt: sampleDate,
+				UpdatedAt: sampleDate,
+			},
+		},
+		{
+			name: "API error, included in response body",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO/sessions/sess1/follow_up"), "api.githubcopilot.com"),
+					httpmock.StatusStringResponse(500, heredoc.Doc(`{
+						"error": {
+							"message": "some error"
+						}
+					}`)),
+				)
+			},
+			wantErr: "failed to create follow-up job: some error",
+		},
+		{
+			name: "API error",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO/sessions/sess1/follow_up"), "api.githubcopilot.com"),
+					httpmock.StatusStringResponse(500, `{}`),
+				)
+			},
+			wantErr: "failed to create follow-up job: 500 Internal Server Error: {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, reg)
+			}
+			defer reg.Verify(t)
+
+			httpClient := &http.Client{Transport: reg}
+
+			cfg := config.NewBlankConfig()
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+			job, err := capiClient.FollowUpJob(context.Background(), "OWNER", "REPO", "sess1", "Keep going")
 
 			if tt.wantErr != "" {
 				require.EqualError(t, err, tt.wantErr)
@@ -11,21 +11,63 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"os"
+	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cli/cli/v2/api"
+	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/shurcooL/githubv4"
 	"github.com/vmihailenco/msgpack/v5"
 )
 
-const AgentsHomeURL = "https://github.com/copilot/agents"
+const defaultAgentsHomeURL = "https://github.com/copilot/agents"
 
-var defaultSessionsPerPage = 50
+// agentsHomeURLEnv is a development-only knob overriding the agents dashboard
+// URL, independent of the GH_COPILOT_API_URL API override.
+const agentsHomeURLEnv = "GH_COPILOT_HOME_URL"
+
+// AgentsHomeURL returns the URL of the Copilot agents dashboard on github.com,
+// honoring the development override when set and valid.
+func AgentsHomeURL() string {
+	if override := os.Getenv(agentsHomeURLEnv); override != "" {
+		if u, err := url.Parse(override); err == nil && u.Scheme == "https" && u.Host != "" {
+			return strings.TrimSuffix(override, "/")
+		}
+	}
+	return defaultAgentsHomeURL
+}
+
+const defaultSessionsPerPage = 50
 
 var ErrSessionNotFound = errors.New("not found")
 
+// Known agent session states. The API has used both the American and British
+// spellings of the cancelled state, so both are recognized throughout.
+const (
+	SessionStateQueued         = "queued"
+	SessionStateInProgress     = "in_progress"
+	SessionStateCompleted      = "completed"
+	SessionStateFailed         = "failed"
+	SessionStateIdle           = "idle"
+	SessionStateWaitingForUser = "waiting_for_user"
+	SessionStateTimedOut       = "timed_out"
+	SessionStateCancelled      = "cancelled"
+	SessionStateCanceled       = "canceled"
+)
+
+const uuidPattern = `[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}`
+
+var sessionIDRegexp = regexp.MustCompile("^" + uuidPattern + "$")
+
+// IsSessionID reports whether s is a well-formed agent session ID (a UUID).
+func IsSessionID(s string) bool {
+	return sessionIDRegexp.MatchString(s)
+}
+
 // session is an in-flight agent task
 type session struct {
 	ID               string    `json:"id"`
@@ -46,6 +88,7 @@ type session struct {
 	EventType        string    `json:"event_type"`
 	PremiumRequests  float64   `json:"premium_requests"`
 	WorkflowRunID    uint64    `json:"workflow_run_id,omitempty"`
+	Result           string    `json:"result,omitempty"`
 	Error            *struct {
 		Code    string `json:"code"`
 		Message string `json:"message"`
@@ -72,6 +115,32 @@ type sessionPullRequest struct {
 	Repository *api.PRRepository
 }
 
+// sessionPullRequestMinimal is the projection requested when the client is
+// configured with WithMinimalPRFields: just what summary displays and JSON
+// export need, leaving out the body and timestamps.
+type sessionPullRequestMinimal struct {
+	ID             string
+	FullDatabaseID string
+	Number         int
+	Title          string
+	State          string
+	URL            string
+	IsDraft        bool
+
+	Repository *api.PRRepository
+}
+
+// sessionIssue is the projection of issue data fetched during hydration for
+// sessions whose resource is an issue rather than a pull request.
+type sessionIssue struct {
+	ID             string
+	FullDatabaseID string
+	Number         int
+	Title          string
+	State          string
+	URL            string
+}
+
 // Session is a hydrated in-flight agent task
 type Session struct {
 	ID              string
@@ -91,10 +160,20 @@ type Session struct {
 	EventType       string
 	PremiumRequests float64
 	WorkflowRunID   uint64
+	Result          string
 	Error           *SessionError
 
 	PullRequest *api.PullRequest
-	User        *api.GitHubUser
+	// Issue carries the hydrated issue data for sessions whose resource is an
+	// issue rather than a pull request.
+	Issue *api.Issue
+	User  *api.GitHubUser
+
+	// PullRequestInaccessible reports that the session is attached to a pull
+	// request but hydration could not resolve it, typically because the pull
+	// request was deleted or the viewer lost access. It lets rendering
+	// distinguish "no pull request yet" from "pull request inaccessible".
+	PullRequestInaccessible bool
 }
 
 type SessionError struct {
@@ -102,6 +181,96 @@ type SessionError struct {
 	Message string
 }
 
+// IsTerminal reports whether the session has reached a final state and will
+// not transition further on its own. This is the single definition of the
+// terminal-state set, used by stop conditions such as --exit-status.
+func (s *Session) IsTerminal() bool {
+	switch s.State {
+	case SessionStateCompleted, SessionStateFailed, SessionStateTimedOut, SessionStateCancelled, SessionStateCanceled:
+		return true
+	}
+	return false
+}
+
+// SessionFields is the set of Session fields that can be exported as JSON.
+var SessionFields = []string{
+	"id",
+	"name",
+	"state",
+	"resourceType",
+	"resourceId",
+	"createdAt",
+	"lastUpdatedAt",
+	"completedAt",
+	"eventType",
+	"eventUrl",
+	"premiumRequests",
+	"workflowRunId",
+	"result",
+	"error",
+	"pullRequest",
+	"issue",
+	"user",
+}
+
+// ExportData implements cmdutil.Exporter field selection. The nested pull
+// request, issue, and user are projected to a small stable set of fields
+// rather than exposing the full API types.
+func (s *Session) ExportData(fields []string) map[string]interface{} {
+	data := cmdutil.StructExportData(s, fields)
+	if _, ok := data["pullRequest"]; ok {
+		data["pullRequest"] = exportSessionPullRequest(s.PullRequest)
+	}
+	if _, ok := data["issue"]; ok {
+		data["issue"] = exportSessionIssue(s.Issue)
+	}
+	if _, ok := data["user"]; ok {
+		data["user"] = exportSessionUser(s.User)
+	}
+	return data
+}
+
+func exportSessionIssue(issue *api.Issue) map[string]interface{} {
+	if issue == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"number": issue.Number,
+		"title":  issue.Title,
+		"state":  issue.State,
+		"url":    issue.URL,
+	}
+}
+
+func exportSessionPullRequest(pr *api.PullRequest) map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	data := map[string]interface{}{
+		"number":  pr.Number,
+		"title":   pr.Title,
+		"state":   pr.State,
+		"url":     pr.URL,
+		"isDraft": pr.IsDraft,
+	}
+	if pr.Repository != nil {
+		data["repository"] = map[string]interface{}{
+			"nameWithOwner": pr.Repository.NameWithOwner,
+		}
+	}
+	return data
+}
+
+func exportSessionUser(u *api.GitHubUser) map[string]interface{} {
+	if u == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":    u.ID,
+		"login": u.Login,
+	}
+}
+
 type resource struct {
 	ID                   string            `json:"id"`
 	UserID               uint64            `json:"user_id"`
@@ -122,29 +291,126 @@ type resourceSession struct {
 	SessionLastUpdatedAt int64  `json:"last_updated_at"`
 }
 
+// errSessionsEndpointNotFound reports that a sessions listing endpoint is not
+// deployed on the target host.
+var errSessionsEndpointNotFound = errors.New("sessions endpoint not found")
+
 // ListLatestSessionsForViewer lists all agent sessions for the
 // authenticated user up to limit.
 func (c *CAPIClient) ListLatestSessionsForViewer(ctx context.Context, limit int) ([]*Session, error) {
+	return c.listLatestSessions(ctx, c.baseURL+"/agents/sessions", limit)
+}
+
+// ListSessionsForOwner lists the authenticated user's agent sessions for
+// repositories belonging to owner, up to limit. It uses the owner-scoped
+// sessions endpoint when available; on hosts where that endpoint is not
+// deployed it falls back to listing the viewer's sessions and filtering them
+// client-side by the owner of each session's pull request repository.
+func (c *CAPIClient) ListSessionsForOwner(ctx context.Context, owner string, limit int) ([]*Session, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("missing owner")
+	}
+
 	if limit == 0 {
 		return nil, nil
 	}
 
-	url := baseCAPIURL + "/agents/sessions"
-	pageSize := defaultSessionsPerPage
+	sessions, err := c.listLatestSessions(ctx, fmt.Sprintf("%s/agents/sessions/owner/%s", c.baseURL, url.PathEscape(owner)), limit)
+	if !errors.Is(err, errSessionsEndpointNotFound) {
+		return sessions, err
+	}
+
+	viewerSessions, err := c.ListLatestSessionsForViewer(ctx, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*Session, 0, limit)
+	for _, s := range viewerSessions {
+		if s.PullRequest == nil || s.PullRequest.Repository == nil {
+			continue
+		}
+		sessionOwner, _, _ := strings.Cut(s.PullRequest.Repository.NameWithOwner, "/")
+		if !strings.EqualFold(sessionOwner, owner) {
+			continue
+		}
+		filtered = append(filtered, s)
+		if len(filtered) >= limit {
+			break
+		}
+	}
+	return filtered, nil
+}
+
+// ListSessionsForRepo lists the authenticated user's agent sessions whose
+// pull request belongs to the given repository, up to limit. Sessions are
+// fetched with the owner scope and filtered client-side by the repository of
+// each session's pull request.
+func (c *CAPIClient) ListSessionsForRepo(ctx context.Context, owner string, repo string, limit int) ([]*Session, error) {
+	if owner == "" || repo == "" {
+		return nil, fmt.Errorf("missing owner/repo")
+	}
+
+	if limit == 0 {
+		return nil, nil
+	}
+
+	ownerSessions, err := c.ListSessionsForOwner(ctx, owner, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+
+	nameWithOwner := owner + "/" + repo
+	filtered := make([]*Session, 0, limit)
+	for _, s := range ownerSessions {
+		if s.PullRequest == nil || s.PullRequest.Repository == nil {
+			continue
+		}
+		if !strings.EqualFold(s.PullRequest.Repository.NameWithOwner, nameWithOwner) {
+			continue
+		}
+		filtered = append(filtered, s)
+		if len(filtered) >= limit {
+			break
+		}
+	}
+	return filtered, nil
+}
+
+func (c *CAPIClient) listLatestSessions(ctx context.Context, url string, limit int) ([]*Session, error) {
+	if limit == 0 {
+		return nil, nil
+	}
+
+	pageSize := c.sessionsPerPage
 
 	seenResources := make(map[int64]struct{})
-	latestSessions := make([]session, 0, limit)
+	latestSessions := make([]session, 0, min(limit, pageSize))
+
+	// nextURL carries the rel="next" target parsed from the previous
+	// response's Link header. When the API provides one it is authoritative;
+	// otherwise pagination falls back to incrementing page_number until a
+	// short page signals the end.
+	var nextURL string
 	for page := 1; ; page++ {
-		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		followingLink := nextURL != ""
+
+		requestURL := url
+		if followingLink {
+			requestURL = nextURL
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, http.NoBody)
 		if err != nil {
 			return nil, err
 		}
 
-		q := req.URL.Query()
-		q.Set("page_size", strconv.Itoa(pageSize))
-		q.Set("page_number", strconv.Itoa(page))
-		q.Set("sort", "last_updated_at,desc")
-		req.URL.RawQuery = q.Encode()
+		if !followingLink {
+			q := req.URL.Query()
+			q.Set("page_size", strconv.Itoa(pageSize))
+			q.Set("page_number", strconv.Itoa(page))
+			q.Set("sort", "last_updated_at,desc")
+			req.URL.RawQuery = q.Encode()
+		}
 
 		res, err := c.httpClient.Do(req)
 		if err != nil {
@@ -152,7 +418,10 @@ func (c *CAPIClient) ListLatestSessionsForViewer(ctx context.Context, limit int)
 		}
 		defer res.Body.Close()
 		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to list sessions: %s", res.Status)
+			if res.StatusCode == http.StatusNotFound {
+				return nil, errSessionsEndpointNotFound
+			}
+			return nil, fmt.Errorf("failed to list sessions: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
 		}
 		var response struct {
 			Sessions []session `json:"sessions"`
@@ -184,9 +453,19 @@ func (c *CAPIClient) ListLatestSessionsForViewer(ctx context.Context, limit int)
 			}
 		}
 
-		if len(response.Sessions) < pageSize || len(latestSessions) >= limit {
+		if len(latestSessions) >= limit {
 			break
 		}
+
+		nextURL = findNextPage(res.Header.Get("Link"))
+		if nextURL == "" {
+			// Once a next link has been followed, its absence marks the final
+			// page even when that page is full. Without links, a short page is
+			// the only end-of-data signal.
+			if followingLink || len(response.Sessions) < pageSize {
+				break
+			}
+		}
 	}
 
 	// Drop any above the limit
@@ -202,13 +481,30 @@ func (c *CAPIClient) ListLatestSessionsForViewer(ctx context.Context, limit int)
 	return result, nil
 }
 
+var linkRE = regexp.MustCompile(`<([^>]+)>;\s*rel="([^"]+)"`)
+
+// findNextPage extracts the rel="next" URL from a Link header value, returning
+// an empty string when there is none.
+func findNextPage(linkValue string) string {
+	for _, m := range linkRE.FindAllStringSubmatch(linkValue, -1) {
+		if len(m) > 2 && m[2] == "next" {
+			return m[1]
+		}
+	}
+	return ""
+}
+
 // GetSession retrieves a specific agent session by ID.
 func (c *CAPIClient) GetSession(ctx context.Context, id string) (*Session, error) {
 	if id == "" {
 		return nil, fmt.Errorf("missing session ID")
 	}
 
-	url := fmt.Sprintf("%s/agents/sessions/%s", baseCAPIURL, url.PathEscape(id))
+	if !IsSessionID(id) {
+		return nil, fmt.Errorf("%q is not a valid session ID", id)
+	}
+
+	url := fmt.Sprintf("%s/agents/sessions/%s", c.baseURL, url.PathEscape(id))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
@@ -225,7 +521,7 @@ func (c *CAPIClient) GetSession(ctx context.Context, id string) (*Session, error
 		if res.StatusCode == http.StatusNotFound {
 			return nil, ErrSessionNotFound
 		}
-		return nil, fmt.Errorf("failed to get session: %s", res.Status)
+		return nil, fmt.Errorf("failed to get session: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
 	}
 
 	var rawSession session
@@ -243,11 +539,75 @@ func (c *CAPIClient) GetSession(ctx context.Context, id string) (*Session, error
 
 // GetSessionLogs retrieves logs of an agent session identified by ID.
 func (c *CAPIClient) GetSessionLogs(ctx context.Context, id string) ([]byte, error) {
+	body, err := c.GetSessionLogsReader(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// GetSessionLogsFrom retrieves logs of an agent session starting at the given
+// byte offset, using an HTTP Range request so polling needn't re-download the
+// whole log on every fetch. The returned bool reports whether the bytes are
+// partial content starting at the offset; a server that ignores the Range
+// header returns the full log with false, and the caller falls back to
+// slicing off the already-seen prefix itself.
+func (c *CAPIClient) GetSessionLogsFrom(ctx context.Context, id string, offset int64) ([]byte, bool, error) {
+	if id == "" {
+		return nil, false, fmt.Errorf("missing session ID")
+	}
+
+	if !IsSessionID(id) {
+		return nil, false, fmt.Errorf("%q is not a valid session ID", id)
+	}
+
+	url := fmt.Sprintf("%s/agents/sessions/%s/logs", c.baseURL, url.PathEscape(id))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, false, err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusPartialContent:
+		raw, err := io.ReadAll(res.Body)
+		return raw, true, err
+	case http.StatusOK:
+		raw, err := io.ReadAll(res.Body)
+		return raw, false, err
+	case http.StatusRequestedRangeNotSatisfiable:
+		// Nothing beyond the offset yet.
+		return nil, true, nil
+	case http.StatusNotFound:
+		return nil, false, ErrSessionNotFound
+	default:
+		return nil, false, fmt.Errorf("failed to get session: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
+	}
+}
+
+// GetSessionLogsReader retrieves logs of an agent session identified by ID as
+// a stream, so very large logs need not be held in memory at once. The caller
+// is responsible for closing the returned reader.
+func (c *CAPIClient) GetSessionLogsReader(ctx context.Context, id string) (io.ReadCloser, error) {
 	if id == "" {
 		return nil, fmt.Errorf("missing session ID")
 	}
 
-	url := fmt.Sprintf("%s/agents/sessions/%s/logs", baseCAPIURL, url.PathEscape(id))
+	if !IsSessionID(id) {
+		return nil, fmt.Errorf("%q is not a valid session ID", id)
+	}
+
+	url := fmt.Sprintf("%s/agents/sessions/%s/logs", c.baseURL, url.PathEscape(id))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
@@ -259,15 +619,15 @@ func (c *CAPIClient) GetSessionLogs(ctx context.Context, id string) ([]byte, err
 		return nil, err
 	}
 
-	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
 		if res.StatusCode == http.StatusNotFound {
 			return nil, ErrSessionNotFound
 		}
-		return nil, fmt.Errorf("failed to get session: %s", res.Status)
+		return nil, fmt.Errorf("failed to get session: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
 	}
 
-	return io.ReadAll(res.Body)
+	return res.Body, nil
 }
 
 // ListSessionsByResourceID retrieves sessions associated with the given resource type and ID.
@@ -280,7 +640,7 @@ func (c *CAPIClient) ListSessionsByResourceID(ctx context.Context, resourceType
 		return nil, nil
 	}
 
-	url := fmt.Sprintf("%s/agents/resource/%s/%d", baseCAPIURL, url.PathEscape(resourceType), resourceID)
+	url := fmt.Sprintf("%s/agents/resource/%s/%d", c.baseURL, url.PathEscape(resourceType), resourceID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
@@ -293,7 +653,7 @@ func (c *CAPIClient) ListSessionsByResourceID(ctx context.Context, resourceType
 	}
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list sessions: %s", res.Status)
+		return nil, fmt.Errorf("failed to list sessions: %s", statusWithBody(res.StatusCode, readErrorBody(res.Body)))
 	}
 
 	var response resource
@@ -325,77 +685,156 @@ func (c *CAPIClient) ListSessionsByResourceID(ctx context.Context, resourceType
 	return result, nil
 }
 
+// ListSessionsForPullRequest retrieves sessions associated with a pull request
+// identified by its number in a repository. It resolves the pull request's
+// database ID and then lists sessions for that resource, so callers don't need
+// to combine GetPullRequestDatabaseID and ListSessionsByResourceID themselves.
+func (c *CAPIClient) ListSessionsForPullRequest(ctx context.Context, hostname string, owner string, repo string, number int, limit int) ([]*Session, error) {
+	prID, _, err := c.GetPullRequestDatabaseID(ctx, hostname, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pull request: %w", err)
+	}
+	return c.ListSessionsByResourceID(ctx, "pull", prID, limit)
+}
+
 // hydrateSessionPullRequestsAndUsers hydrates pull request and user information in sessions
 func (c *CAPIClient) hydrateSessionPullRequestsAndUsers(sessions []session) ([]*Session, error) {
 	if len(sessions) == 0 {
 		return nil, nil
 	}
 
+	if c.skipHydration {
+		newSessions := make([]*Session, 0, len(sessions))
+		for _, s := range sessions {
+			newSessions = append(newSessions, fromAPISession(s))
+		}
+		return newSessions, nil
+	}
+
 	prNodeIds := make([]string, 0, len(sessions))
+	issueNodeIds := make([]string, 0, len(sessions))
 	userNodeIds := make([]string, 0, len(sessions))
 	for _, session := range sessions {
-		if session.ResourceType == "pull" {
+		switch session.ResourceType {
+		case "pull":
 			prNodeID := session.ResourceGlobalID
 			// TODO: probably this can be dropped since the API should always
 			// keep returning the resource global ID.
 			if session.ResourceGlobalID == "" {
-				prNodeID = generatePullRequestNodeID(int64(session.RepoID), session.ResourceID)
+				var err error
+				prNodeID, err = generatePullRequestNodeID(int64(session.RepoID), session.ResourceID)
+				if err != nil {
+					return nil, err
+				}
 			}
 			if !slices.Contains(prNodeIds, prNodeID) {
 				prNodeIds = append(prNodeIds, prNodeID)
 			}
+		case "issue":
+			issueNodeID := session.ResourceGlobalID
+			if session.ResourceGlobalID == "" {
+				var err error
+				issueNodeID, err = generateIssueNodeID(int64(session.RepoID), session.ResourceID)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if !slices.Contains(issueNodeIds, issueNodeID) {
+				issueNodeIds = append(issueNodeIds, issueNodeID)
+			}
 		}
 
-		userNodeId := generateUserNodeID(session.UserID)
+		userNodeId, err := generateUserNodeID(session.UserID)
+		if err != nil {
+			return nil, err
+		}
 		if !slices.Contains(userNodeIds, userNodeId) {
 			userNodeIds = append(userNodeIds, userNodeId)
 		}
 	}
 	apiClient := api.NewClientFromHTTP(c.httpClient)
 
-	var resp struct {
-		Nodes []struct {
-			TypeName    string             `graphql:"__typename"`
-			PullRequest sessionPullRequest `graphql:"... on PullRequest"`
-			User        api.GitHubUser     `graphql:"... on User"`
-		} `graphql:"nodes(ids: $ids)"`
-	}
-
-	ids := make([]string, 0, len(prNodeIds)+len(userNodeIds))
+	ids := make([]string, 0, len(prNodeIds)+len(issueNodeIds)+len(userNodeIds))
 	ids = append(ids, prNodeIds...)
+	ids = append(ids, issueNodeIds...)
 	ids = append(ids, userNodeIds...)
 
 	// TODO handle pagination
 	host, _ := c.authCfg.DefaultHost()
-	err := apiClient.Query(host, "FetchPRsAndUsersForAgentTaskSessions", &resp, map[string]any{
-		"ids": ids,
-	})
-
-	if err != nil {
-		return nil, err
-	}
 
 	prMap := make(map[string]*api.PullRequest, len(prNodeIds))
+	issueMap := make(map[string]*api.Issue, len(issueNodeIds))
 	userMap := make(map[int64]*api.GitHubUser, len(userNodeIds))
-	for _, node := range resp.Nodes {
-		switch node.TypeName {
-		case "User":
-			userMap[node.User.DatabaseID] = &node.User
-		case "PullRequest":
-			prMap[node.PullRequest.FullDatabaseID] = &api.PullRequest{
-				ID:             node.PullRequest.ID,
-				FullDatabaseID: node.PullRequest.FullDatabaseID,
-				Number:         node.PullRequest.Number,
-				Title:          node.PullRequest.Title,
-				State:          node.PullRequest.State,
-				IsDraft:        node.PullRequest.IsDraft,
-				URL:            node.PullRequest.URL,
-				Body:           node.PullRequest.Body,
-				CreatedAt:      node.PullRequest.CreatedAt,
-				UpdatedAt:      node.PullRequest.UpdatedAt,
-				ClosedAt:       node.PullRequest.ClosedAt,
-				MergedAt:       node.PullRequest.MergedAt,
-				Repository:     node.PullRequest.Repository,
+
+	if c.minimalPRFields {
+		var resp struct {
+			Nodes []struct {
+				TypeName    string                    `graphql:"__typename"`
+				PullRequest sessionPullRequestMinimal `graphql:"... on PullRequest"`
+				Issue       sessionIssue              `graphql:"... on Issue"`
+				User        api.GitHubUser            `graphql:"... on User"`
+			} `graphql:"nodes(ids: $ids)"`
+		}
+		if err := apiClient.Query(host, "FetchPRsAndUsersForAgentTaskSessions", &resp, map[string]any{
+			"ids": ids,
+		}); err != nil {
+			return nil, err
+		}
+		for _, node := range resp.Nodes {
+			switch node.TypeName {
+			case "User":
+				userMap[node.User.DatabaseID] = &node.User
+			case "PullRequest":
+				prMap[node.PullRequest.FullDatabaseID] = &api.PullRequest{
+					ID:             node.PullRequest.ID,
+					FullDatabaseID: node.PullRequest.FullDatabaseID,
+					Number:         node.PullRequest.Number,
+					Title:          node.PullRequest.Title,
+					State:          node.PullRequest.State,
+					IsDraft:        node.PullRequest.IsDraft,
+					URL:            node.PullRequest.URL,
+					Repository:     node.PullRequest.Repository,
+				}
+			case "Issue":
+				issueMap[node.Issue.FullDatabaseID] = issueFromSessionIssue(node.Issue)
+			}
+		}
+	} else {
+		var resp struct {
+			Nodes []struct {
+				TypeName    string             `graphql:"__typename"`
+				PullRequest sessionPullRequest `graphql:"... on PullRequest"`
+				Issue       sessionIssue       `graphql:"... on Issue"`
+				User        api.GitHubUser     `graphql:"... on User"`
+			} `graphql:"nodes(ids: $ids)"`
+		}
+		if err := apiClient.Query(host, "FetchPRsAndUsersForAgentTaskSessions", &resp, map[string]any{
+			"ids": ids,
+		}); err != nil {
+			return nil, err
+		}
+		for _, node := range resp.Nodes {
+			switch node.TypeName {
+			case "User":
+				userMap[node.User.DatabaseID] = &node.User
+			case "PullRequest":
+				prMap[node.PullRequest.FullDatabaseID] = &api.PullRequest{
+					ID:             node.PullRequest.ID,
+					FullDatabaseID: node.PullRequest.FullDatabaseID,
+					Number:         node.PullRequest.Number,
+					Title:          node.PullRequest.Title,
+					State:          node.PullRequest.State,
+					IsDraft:        node.PullRequest.IsDraft,
+					URL:            node.PullRequest.URL,
+					Body:           node.PullRequest.Body,
+					CreatedAt:      node.PullRequest.CreatedAt,
+					UpdatedAt:      node.PullRequest.UpdatedAt,
+					ClosedAt:       node.PullRequest.ClosedAt,
+					MergedAt:       node.PullRequest.MergedAt,
+					Repository:     node.PullRequest.Repository,
+				}
+			case "Issue":
+				issueMap[node.Issue.FullDatabaseID] = issueFromSessionIssue(node.Issue)
 			}
 		}
 	}
@@ -404,7 +843,14 @@ func (c *CAPIClient) hydrateSessionPullRequestsAndUsers(sessions []session) ([]*
 	for _, s := range sessions {
 		newSession := fromAPISession(s)
 		newSession.PullRequest = prMap[strconv.FormatInt(s.ResourceID, 10)]
+		newSession.Issue = issueMap[strconv.FormatInt(s.ResourceID, 10)]
 		newSession.User = userMap[s.UserID]
+		// A "pull" session always has an associated pull request, so a miss
+		// here means the node was absent from the GraphQL response: the pull
+		// request was deleted or the viewer cannot see it.
+		if newSession.PullRequest == nil && s.ResourceType == "pull" {
+			newSession.PullRequestInaccessible = true
+		}
 		newSessions = append(newSessions, newSession)
 	}
 
@@ -449,36 +895,46 @@ func (c *CAPIClient) GetPullRequestDatabaseID(ctx context.Context, hostname stri
 
 // generatePullRequestNodeID converts an int64 databaseID and repoID to a GraphQL Node ID format
 // with the "PR_" prefix for pull requests
-func generatePullRequestNodeID(repoID, pullRequestID int64) string {
-	buf := bytes.Buffer{}
-	parts := []int64{0, repoID, pullRequestID}
-
-	encoder := msgpack.NewEncoder(&buf)
-	encoder.UseCompactInts(true)
-
-	if err := encoder.Encode(parts); err != nil {
-		panic(err)
-	}
+func generatePullRequestNodeID(repoID, pullRequestID int64) (string, error) {
+	return generateNodeID("PR_", []int64{0, repoID, pullRequestID})
+}
 
-	encoded := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+// generateIssueNodeID converts an int64 databaseID and repoID to a GraphQL
+// Node ID format with the "I_" prefix for issues
+func generateIssueNodeID(repoID, issueID int64) (string, error) {
+	return generateNodeID("I_", []int64{0, repoID, issueID})
+}
 
-	return "PR_" + encoded
+func generateUserNodeID(userID int64) (string, error) {
+	return generateNodeID("U_", []int64{0, userID})
 }
 
-func generateUserNodeID(userID int64) string {
+// generateNodeID msgpack-encodes the given ID parts and prefixes them the way
+// GraphQL node IDs are formed. Encoding a slice of integers should never
+// realistically fail, but the error is propagated rather than panicking so a
+// malformed session can't crash the CLI.
+func generateNodeID(prefix string, parts []int64) (string, error) {
 	buf := bytes.Buffer{}
-	parts := []int64{0, userID}
-
 	encoder := msgpack.NewEncoder(&buf)
 	encoder.UseCompactInts(true)
 
 	if err := encoder.Encode(parts); err != nil {
-		panic(err)
+		return "", fmt.Errorf("failed to encode node ID: %w", err)
 	}
 
-	encoded := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	return prefix + base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
 
-	return "U_" + encoded
+// issueFromSessionIssue maps the hydration projection onto the shared
+// api.Issue type used by the rest of the CLI.
+func issueFromSessionIssue(i sessionIssue) *api.Issue {
+	return &api.Issue{
+		ID:     i.ID,
+		Number: i.Number,
+		Title:  i.Title,
+		State:  i.State,
+		URL:    i.URL,
+	}
 }
 
 func fromAPISession(s session) *Session {
@@ -500,6 +956,7 @@ func fromAPISession(s session) *Session {
 		EventType:       s.EventType,
 		PremiumRequests: s.PremiumRequests,
 		WorkflowRunID:   s.WorkflowRunID,
+		Result:          s.Result,
 	}
 	if s.Error != nil {
 		result.Error = &SessionError{
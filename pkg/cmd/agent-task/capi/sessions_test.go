@@ -2,8 +2,11 @@ package capi
 
 import (
 	"context"
+	"io"
+	"math"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -241,6 +244,88 @@ func TestListLatestSessionsForViewer(t *testing.T) {
 				},
 			},
 		},
+		{
+			// The pull request node can be absent from the GraphQL response when
+			// the pull request was deleted or the viewer lost access to it.
+			name:  "single session, pull request node missing from hydration",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(heredoc.Docf(`
+						{
+							"sessions": [
+								{
+									"id": "sess1",
+									"name": "Build artifacts",
+									"user_id": 1,
+									"agent_id": 2,
+									"logs": "",
+									"state": "completed",
+									"owner_id": 10,
+									"repo_id": 1000,
+									"resource_type": "pull",
+									"resource_id": 2000,
+									"created_at": "%[1]s",
+									"premium_requests": 0.1
+								}
+							]
+						}`,
+						sampleDateString,
+					)),
+				)
+				// GraphQL hydration: the PR node ID was requested but the
+				// response only carries the user node.
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.GraphQLQuery(heredoc.Doc(`
+						{
+							"data": {
+								"nodes": [
+									{
+										"__typename": "User",
+										"login": "octocat",
+										"name": "Octocat",
+										"databaseId": 1
+									}
+								]
+							}
+						}`,
+					), func(q string, vars map[string]interface{}) {
+						assert.Equal(t, []interface{}{"PR_kwDNA-jNB9A", "U_kgAB"}, vars["ids"])
+					}),
+				)
+			},
+			wantOut: []*Session{
+				{
+
+					ID:                      "sess1",
+					Name:                    "Build artifacts",
+					UserID:                  1,
+					AgentID:                 2,
+					Logs:                    "",
+					State:                   "completed",
+					OwnerID:                 10,
+					RepoID:                  1000,
+					ResourceType:            "pull",
+					ResourceID:              2000,
+					CreatedAt:               sampleDate,
+					PremiumRequests:         0.1,
+					PullRequestInaccessible: true,
+					User: &api.GitHubUser{
+						Login:      "octocat",
+						Name:       "Octocat",
+						DatabaseID: 1,
+					},
+				},
+			},
+		},
 		{
 			name:    "multiple sessions, paginated",
 			perPage: 1, // to enforce pagination
@@ -433,6 +518,223 @@ func TestListLatestSessionsForViewer(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "single session with issue resource",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(heredoc.Docf(`
+						{
+							"sessions": [
+								{
+									"id": "sess1",
+									"name": "Investigate flaky test",
+									"user_id": 1,
+									"agent_id": 2,
+									"logs": "",
+									"state": "completed",
+									"owner_id": 10,
+									"repo_id": 1000,
+									"resource_type": "issue",
+									"resource_id": 2000,
+									"created_at": "%[1]s",
+									"premium_requests": 0.1
+								}
+							]
+						}`,
+						sampleDateString,
+					)),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.GraphQLQuery(heredoc.Doc(`
+						{
+							"data": {
+								"nodes": [
+									{
+										"__typename": "Issue",
+										"id": "I_node",
+										"fullDatabaseId": "2000",
+										"number": 7,
+										"title": "Flaky test",
+										"state": "OPEN",
+										"url": "https://github.com/OWNER/REPO/issues/7"
+									},
+									{
+										"__typename": "User",
+										"login": "octocat",
+										"name": "Octocat",
+										"databaseId": 1
+									}
+								]
+							}
+						}`,
+					), func(q string, vars map[string]interface{}) {
+						assert.Equal(t, []interface{}{"I_kwDNA-jNB9A", "U_kgAB"}, vars["ids"])
+					}),
+				)
+			},
+			wantOut: []*Session{
+				{
+					ID:              "sess1",
+					Name:            "Investigate flaky test",
+					UserID:          1,
+					AgentID:         2,
+					Logs:            "",
+					State:           "completed",
+					OwnerID:         10,
+					RepoID:          1000,
+					ResourceType:    "issue",
+					ResourceID:      2000,
+					CreatedAt:       sampleDate,
+					PremiumRequests: 0.1,
+					Issue: &api.Issue{
+						ID:     "I_node",
+						Number: 7,
+						Title:  "Flaky test",
+						State:  "OPEN",
+						URL:    "https://github.com/OWNER/REPO/issues/7",
+					},
+					User: &api.GitHubUser{
+						Login:      "octocat",
+						Name:       "Octocat",
+						DatabaseID: 1,
+					},
+				},
+			},
+		},
+		{
+			// An effectively infinite limit never trips the early break, so the
+			// empty page is the only end-of-data signal.
+			name:    "effectively infinite limit stops at the empty page",
+			perPage: 1,
+			limit:   math.MaxInt,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"1"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(heredoc.Docf(`
+						{
+							"sessions": [
+								{
+									"id": "sess1",
+									"name": "Build artifacts",
+									"user_id": 1,
+									"agent_id": 2,
+									"logs": "",
+									"state": "completed",
+									"owner_id": 10,
+									"repo_id": 1000,
+									"resource_type": "pull",
+									"resource_id": 2000,
+									"created_at": "%[1]s",
+									"premium_requests": 0.1
+								}
+							]
+						}`,
+						sampleDateString,
+					)),
+				)
+
+				// Second page is empty, which ends the pagination.
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"2"},
+							"page_size":   {"1"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(`{"sessions":[]}`),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.GraphQLQuery(heredoc.Docf(`
+						{
+							"data": {
+								"nodes": [
+									{
+										"__typename": "PullRequest",
+										"id": "PR_node",
+										"fullDatabaseId": "2000",
+										"number": 42,
+										"title": "Improve docs",
+										"state": "OPEN",
+										"isDraft": true,
+										"url": "https://github.com/OWNER/REPO/pull/42",
+										"body": "",
+										"createdAt": "%[1]s",
+										"updatedAt": "%[1]s",
+										"repository": {
+											"nameWithOwner": "OWNER/REPO"
+										}
+									},
+									{
+										"__typename": "User",
+										"login": "octocat",
+										"name": "Octocat",
+										"databaseId": 1
+									}
+								]
+							}
+						}`,
+						sampleDateString,
+					), func(q string, vars map[string]interface{}) {
+						assert.Equal(t, []interface{}{"PR_kwDNA-jNB9A", "U_kgAB"}, vars["ids"])
+					}),
+				)
+			},
+			wantOut: []*Session{
+				{
+					ID:              "sess1",
+					Name:            "Build artifacts",
+					UserID:          1,
+					AgentID:         2,
+					Logs:            "",
+					State:           "completed",
+					OwnerID:         10,
+					RepoID:          1000,
+					ResourceType:    "pull",
+					ResourceID:      2000,
+					CreatedAt:       sampleDate,
+					PremiumRequests: 0.1,
+					PullRequest: &api.PullRequest{
+						ID:             "PR_node",
+						FullDatabaseID: "2000",
+						Number:         42,
+						Title:          "Improve docs",
+						State:          "OPEN",
+						IsDraft:        true,
+						URL:            "https://github.com/OWNER/REPO/pull/42",
+						Body:           "",
+						CreatedAt:      sampleDate,
+						UpdatedAt:      sampleDate,
+						Repository: &api.PRRepository{
+							NameWithOwner: "OWNER/REPO",
+						},
+					},
+					User: &api.GitHubUser{
+						Login:      "octocat",
+						Name:       "Octocat",
+						DatabaseID: 1,
+					},
+				},
+			},
+		},
 		{
 			name:    "multiple pages with duplicates per PR only newest kept",
 			perPage: 2,
@@ -1164,15 +1466,11 @@ func TestListLatestSessionsForViewer(t *testing.T) {
 			httpClient := &http.Client{Transport: reg}
 
 			cfg := config.NewBlankConfig()
-			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
-
+			var clientOpts []CAPIClientOption
 			if tt.perPage != 0 {
-				last := defaultSessionsPerPage
-				defaultSessionsPerPage = tt.perPage
-				defer func() {
-					defaultSessionsPerPage = last
-				}()
+				clientOpts = append(clientOpts, WithSessionsPerPage(tt.perPage))
 			}
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication(), clientOpts...)
 
 			sessions, err := capiClient.ListLatestSessionsForViewer(context.Background(), tt.limit)
 
@@ -1544,15 +1842,11 @@ func TestListSessionsByResourceID(t *testing.T) {
 			httpClient := &http.Client{Transport: reg}
 
 			cfg := config.NewBlankConfig()
-			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
-
+			var clientOpts []CAPIClientOption
 			if tt.perPage != 0 {
-				last := defaultSessionsPerPage
-				defaultSessionsPerPage = tt.perPage
-				defer func() {
-					defaultSessionsPerPage = last
-				}()
+				clientOpts = append(clientOpts, WithSessionsPerPage(tt.perPage))
 			}
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication(), clientOpts...)
 
 			sessions, err := capiClient.ListSessionsByResourceID(context.Background(), resourceType, resourceID, tt.limit)
 
@@ -1575,6 +1869,166 @@ func TestGetSessionRequiresID(t *testing.T) {
 	assert.EqualError(t, err, "missing session ID")
 }
 
+func TestGetSessionRejectsInvalidID(t *testing.T) {
+	client := &CAPIClient{}
+
+	_, err := client.GetSession(context.Background(), "not-a-uuid")
+	assert.EqualError(t, err, `"not-a-uuid" is not a valid session ID`)
+
+	_, err = client.GetSessionLogs(context.Background(), "not-a-uuid")
+	assert.EqualError(t, err, `"not-a-uuid" is not a valid session ID`)
+
+	_, err = client.GetSessionLogsReader(context.Background(), "not-a-uuid")
+	assert.EqualError(t, err, `"not-a-uuid" is not a valid session ID`)
+
+	_, _, err = client.GetSessionLogsFrom(context.Background(), "not-a-uuid", 0)
+	assert.EqualError(t, err, `"not-a-uuid" is not a valid session ID`)
+}
+
+func TestGetSessionLogsFrom(t *testing.T) {
+	sessionID := "e2fa49d2-f164-4a56-ab99-498090b8fcdf"
+
+	newClient := func(reg *httpmock.Registry) *CAPIClient {
+		cfg := config.NewBlankConfig()
+		return NewCAPIClient(&http.Client{Transport: reg}, cfg.Authentication())
+	}
+
+	t.Run("requests a range and returns partial content", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "bytes=5-", req.Header.Get("Range"))
+				return &http.Response{
+					StatusCode: 206,
+					Request:    req,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("data: two\n")),
+				}, nil
+			},
+		)
+		defer reg.Verify(t)
+
+		raw, partial, err := newClient(reg).GetSessionLogsFrom(context.Background(), sessionID, 5)
+		require.NoError(t, err)
+		assert.True(t, partial)
+		assert.Equal(t, "data: two\n", string(raw))
+	})
+
+	t.Run("falls back to the full log when the server ignores the range", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			httpmock.StringResponse("data: one\ndata: two\n"),
+		)
+		defer reg.Verify(t)
+
+		raw, partial, err := newClient(reg).GetSessionLogsFrom(context.Background(), sessionID, 5)
+		require.NoError(t, err)
+		assert.False(t, partial)
+		assert.Equal(t, "data: one\ndata: two\n", string(raw))
+	})
+
+	t.Run("no new bytes beyond the offset", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(416, ""),
+		)
+		defer reg.Verify(t)
+
+		raw, partial, err := newClient(reg).GetSessionLogsFrom(context.Background(), sessionID, 20)
+		require.NoError(t, err)
+		assert.True(t, partial)
+		assert.Empty(t, raw)
+	})
+
+	t.Run("zero offset sends no range header", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			func(req *http.Request) (*http.Response, error) {
+				assert.Empty(t, req.Header.Get("Range"))
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader("data: one\n")),
+				}, nil
+			},
+		)
+		defer reg.Verify(t)
+
+		raw, partial, err := newClient(reg).GetSessionLogsFrom(context.Background(), sessionID, 0)
+		require.NoError(t, err)
+		assert.False(t, partial)
+		assert.Equal(t, "data: one\n", string(raw))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(404, `{}`),
+		)
+		defer reg.Verify(t)
+
+		_, _, err := newClient(reg).GetSessionLogsFrom(context.Background(), sessionID, 0)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
+func TestGetSessionLogsReader(t *testing.T) {
+	sessionID := "e2fa49d2-f164-4a56-ab99-498090b8fcdf"
+
+	t.Run("streams a chunked body", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		// The body is delivered in chunks to make sure the reader surfaces
+		// the response as a stream rather than requiring a full read.
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Request:    req,
+					Header:     http.Header{},
+					Body: io.NopCloser(io.MultiReader(
+						strings.NewReader("data: one\n"),
+						strings.NewReader("data: two\n"),
+					)),
+				}, nil
+			},
+		)
+		defer reg.Verify(t)
+
+		cfg := config.NewBlankConfig()
+		client := NewCAPIClient(&http.Client{Transport: reg}, cfg.Authentication())
+
+		rc, err := client.GetSessionLogsReader(context.Background(), sessionID)
+		require.NoError(t, err)
+		defer rc.Close()
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "data: one\ndata: two\n", string(content))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/"+sessionID+"/logs"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(404, `{}`),
+		)
+		defer reg.Verify(t)
+
+		cfg := config.NewBlankConfig()
+		client := NewCAPIClient(&http.Client{Transport: reg}, cfg.Authentication())
+
+		_, err := client.GetSessionLogsReader(context.Background(), sessionID)
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+	})
+}
+
 func TestGetSession(t *testing.T) {
 	sampleDateString := "2025-08-29T00:00:00Z"
 	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
@@ -1591,7 +2045,7 @@ func TestGetSession(t *testing.T) {
 			name: "session not found",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StatusStringResponse(404, "{}"),
 				)
 			},
@@ -1602,7 +2056,7 @@ func TestGetSession(t *testing.T) {
 			name: "API error",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StatusStringResponse(500, "some error"),
 				)
 			},
@@ -1612,7 +2066,7 @@ func TestGetSession(t *testing.T) {
 			name: "invalid JSON response",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StatusStringResponse(200, ""),
 				)
 			},
@@ -1622,10 +2076,10 @@ func TestGetSession(t *testing.T) {
 			name: "success",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StringResponse(heredoc.Docf(`
 						{
-							"id": "some-uuid",
+							"id": "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 							"name": "Build artifacts",
 							"user_id": 1,
 							"agent_id": 2,
@@ -1636,7 +2090,8 @@ func TestGetSession(t *testing.T) {
 							"resource_type": "pull",
 							"resource_id": 2000,
 							"created_at": "%[1]s",
-							"premium_requests": 0.1
+							"premium_requests": 0.1,
+							"result": "All checks passing; opened a pull request with the fix."
 						}`,
 						sampleDateString,
 					)),
@@ -1680,7 +2135,7 @@ func TestGetSession(t *testing.T) {
 				)
 			},
 			wantOut: &Session{
-				ID:              "some-uuid",
+				ID:              "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 				Name:            "Build artifacts",
 				UserID:          1,
 				AgentID:         2,
@@ -1692,6 +2147,7 @@ func TestGetSession(t *testing.T) {
 				ResourceID:      2000,
 				CreatedAt:       sampleDate,
 				PremiumRequests: 0.1,
+				Result:          "All checks passing; opened a pull request with the fix.",
 				PullRequest: &api.PullRequest{
 					ID:             "PR_node",
 					FullDatabaseID: "2000",
@@ -1719,10 +2175,10 @@ func TestGetSession(t *testing.T) {
 			name: "success, but no pull request resource",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StringResponse(heredoc.Docf(`
 						{
-							"id": "some-uuid",
+							"id": "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 							"name": "Build artifacts",
 							"user_id": 1,
 							"agent_id": 2,
@@ -1761,7 +2217,7 @@ func TestGetSession(t *testing.T) {
 				)
 			},
 			wantOut: &Session{
-				ID:              "some-uuid",
+				ID:              "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 				Name:            "Build artifacts",
 				UserID:          1,
 				AgentID:         2,
@@ -1784,10 +2240,10 @@ func TestGetSession(t *testing.T) {
 			name: "API error at hydration",
 			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
 				reg.Register(
-					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/some-uuid"), "api.githubcopilot.com"),
+					httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
 					httpmock.StringResponse(heredoc.Docf(`
 						{
-							"id": "some-uuid",
+							"id": "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 							"name": "Build artifacts",
 							"user_id": 1,
 							"agent_id": 2,
@@ -1826,7 +2282,7 @@ func TestGetSession(t *testing.T) {
 			cfg := config.NewBlankConfig()
 			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
 
-			session, err := capiClient.GetSession(context.Background(), "some-uuid")
+			session, err := capiClient.GetSession(context.Background(), "e2fa49d2-f164-4a56-ab99-498090b8fcdf")
 
 			if tt.wantErrIs != nil {
 				require.ErrorIs(t, err, tt.wantErrIs)
@@ -1917,3 +2373,894 @@ func TestGetPullRequestDatabaseID(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionErrorsIncludeResponseBody(t *testing.T) {
+	t.Run("list sessions", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(503, `{"message":"capacity exceeded"}`),
+		)
+
+		capiClient := NewCAPIClient(&http.Client{Transport: reg}, config.NewBlankConfig().Authentication())
+
+		_, err := capiClient.ListLatestSessionsForViewer(context.Background(), 10)
+		require.EqualError(t, err, `failed to list sessions: 503 Service Unavailable: {"message":"capacity exceeded"}`)
+	})
+
+	t.Run("get session", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(403, `{"message":"access denied"}`),
+		)
+
+		capiClient := NewCAPIClient(&http.Client{Transport: reg}, config.NewBlankConfig().Authentication())
+
+		_, err := capiClient.GetSession(context.Background(), "e2fa49d2-f164-4a56-ab99-498090b8fcdf")
+		require.EqualError(t, err, `failed to get session: 403 Forbidden: {"message":"access denied"}`)
+	})
+
+	t.Run("long bodies are truncated", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("GET", "agents/sessions"), "api.githubcopilot.com"),
+			httpmock.StatusStringResponse(500, strings.Repeat("x", 2*errorBodyMaxLen)),
+		)
+
+		capiClient := NewCAPIClient(&http.Client{Transport: reg}, config.NewBlankConfig().Authentication())
+
+		_, err := capiClient.ListLatestSessionsForViewer(context.Background(), 10)
+		require.EqualError(t, err, "failed to list sessions: 500 Internal Server Error: "+strings.Repeat("x", errorBodyMaxLen))
+	})
+}
+
+func TestListLatestSessionsForViewerWithoutHydration(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
+	require.NoError(t, err)
+
+	reg := &httpmock.Registry{}
+	// Only the sessions endpoint is stubbed; a hydration GraphQL request
+	// would fail the test as an unmatched request.
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"50"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StringResponse(heredoc.Docf(`
+			{
+				"sessions": [
+					{
+						"id": "sess1",
+						"name": "Build artifacts",
+						"user_id": 1,
+						"state": "completed",
+						"owner_id": 10,
+						"repo_id": 1000,
+						"resource_type": "pull",
+						"resource_id": 2000,
+						"created_at": "%[1]s"
+					}
+				]
+			}`,
+			sampleDateString,
+		)),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithoutHydration())
+
+	sessions, err := capiClient.ListLatestSessionsForViewer(context.Background(), 10)
+	require.NoError(t, err)
+
+	require.Equal(t, []*Session{
+		{
+			ID:           "sess1",
+			Name:         "Build artifacts",
+			UserID:       1,
+			State:        "completed",
+			OwnerID:      10,
+			RepoID:       1000,
+			ResourceType: "pull",
+			ResourceID:   2000,
+			CreatedAt:    sampleDate,
+		},
+	}, sessions)
+}
+
+func TestListLatestSessionsForViewerWithMinimalPRFields(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"50"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StringResponse(heredoc.Docf(`
+			{
+				"sessions": [
+					{
+						"id": "sess1",
+						"name": "Build artifacts",
+						"user_id": 1,
+						"state": "completed",
+						"owner_id": 10,
+						"repo_id": 1000,
+						"resource_type": "pull",
+						"resource_id": 2000,
+						"created_at": "%[1]s"
+					}
+				]
+			}`,
+			sampleDateString,
+		)),
+	)
+	reg.Register(
+		httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+		httpmock.GraphQLQuery(`
+			{
+				"data": {
+					"nodes": [
+						{
+							"__typename": "PullRequest",
+							"id": "PR_node",
+							"fullDatabaseId": "2000",
+							"number": 42,
+							"title": "Improve docs",
+							"state": "OPEN",
+							"isDraft": false,
+							"url": "https://github.com/OWNER/REPO/pull/42",
+							"repository": {
+								"nameWithOwner": "OWNER/REPO"
+							}
+						},
+						{
+							"__typename": "User",
+							"login": "octocat",
+							"databaseId": 1
+						}
+					]
+				}
+			}`, func(q string, vars map[string]interface{}) {
+			assert.NotContains(t, q, "body", "expected the minimal projection to omit the PR body")
+			assert.Contains(t, q, "number")
+		}),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithMinimalPRFields())
+
+	sessions, err := capiClient.ListLatestSessionsForViewer(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.NotNil(t, sessions[0].PullRequest)
+	assert.Equal(t, 42, sessions[0].PullRequest.Number)
+	assert.Equal(t, "OWNER/REPO", sessions[0].PullRequest.Repository.NameWithOwner)
+	assert.Empty(t, sessions[0].PullRequest.Body)
+}
+
+func TestListLatestSessionsForViewerFollowsLinkHeader(t *testing.T) {
+	sessionBody := func(id string, resourceID int) string {
+		return heredoc.Docf(`
+			{
+				"sessions": [
+					{
+						"id": "%[1]s",
+						"name": "Build artifacts",
+						"user_id": 1,
+						"state": "completed",
+						"resource_type": "pull",
+						"resource_id": %[2]d
+					}
+				]
+			}`,
+			id, resourceID,
+		)
+	}
+
+	reg := &httpmock.Registry{}
+	// The first page is full and carries a next link; the final page is also
+	// full but has no link, which must end the loop. Relying on the
+	// short-page heuristic here would issue a third, unmatched request.
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"1"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.WithHeader(
+			httpmock.StringResponse(sessionBody("sess1", 2000)),
+			"Link",
+			`<https://api.githubcopilot.com/agents/sessions?cursor=abc>; rel="next"`,
+		),
+	)
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"cursor": {"abc"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StringResponse(sessionBody("sess2", 2001)),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication(), WithSessionsPerPage(1), WithoutHydration())
+
+	sessions, err := capiClient.ListLatestSessionsForViewer(context.Background(), 10)
+	require.NoError(t, err)
+
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "sess1", sessions[0].ID)
+	assert.Equal(t, "sess2", sessions[1].ID)
+}
+
+func TestListSessionsForPullRequest(t *testing.T) {
+	sampleDateString := "2025-08-29T07:00:00Z"
+	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
+	require.NoError(t, err)
+	sampleDateTimestamp := sampleDate.Unix()
+
+	tests := []struct {
+		name      string
+		httpStubs func(*testing.T, *httpmock.Registry)
+		wantErr   string
+		wantOut   []*Session
+	}{
+		{
+			name: "pull request lookup failure",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.GraphQL(`query GetPullRequestFullDatabaseID\b`), "api.github.com"),
+					httpmock.StringResponse(`{"data":{}, "errors": [{"message": "some gql error"}]}`),
+				)
+			},
+			wantErr: "failed to fetch pull request: GraphQL: some gql error",
+		},
+		{
+			name: "resolves the pull request and lists its sessions",
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(httpmock.GraphQL(`query GetPullRequestFullDatabaseID\b`), "api.github.com"),
+					httpmock.GraphQLQuery(`{"data": {"repository": {"pullRequest": {"fullDatabaseId": "2000", "url": "some-url"}}}}`, func(s string, m map[string]interface{}) {
+						assert.Equal(t, "OWNER", m["owner"])
+						assert.Equal(t, "REPO", m["repo"])
+						assert.Equal(t, float64(42), m["number"])
+					}),
+				)
+				reg.Register(
+					httpmock.WithHost(httpmock.REST("GET", "agents/resource/pull/2000"), "api.githubcopilot.com"),
+					httpmock.StringResponse(heredoc.Docf(`
+						{
+							"id": "resource:pull:2000",
+							"user_id": 1,
+							"resource_global_id": "PR_kwDNA-jNB9A",
+							"resource_type": "pull",
+							"resource_id": 2000,
+							"session_count": 1,
+							"last_updated_at": %[1]d,
+							"state": "completed",
+							"resource_state": "draft",
+							"sessions": [
+								{
+									"id": "sess1",
+									"name": "Build artifacts",
+									"state": "completed",
+									"last_updated_at": %[1]d
+								}
+							]
+						}`,
+						sampleDateTimestamp,
+					)),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.GraphQLQuery(heredoc.Docf(`
+						{
+							"data": {
+								"nodes": [
+									{
+										"__typename": "PullRequest",
+										"id": "PR_node",
+										"fullDatabaseId": "2000",
+										"number": 42,
+										"title": "Improve docs",
+										"state": "OPEN",
+										"isDraft": true,
+										"url": "https://github.com/OWNER/REPO/pull/42",
+										"body": "",
+										"createdAt": "%[1]s",
+										"updatedAt": "%[1]s",
+										"repository": {
+											"nameWithOwner": "OWNER/REPO"
+										}
+									},
+									{
+										"__typename": "User",
+										"login": "octocat",
+										"name": "Octocat",
+										"databaseId": 1
+									}
+								]
+							}
+						}`,
+						sampleDateString,
+					), func(q string, vars map[string]interface{}) {
+						assert.Equal(t, []interface{}{"PR_kwDNA-jNB9A", "U_kgAB"}, vars["ids"])
+					}),
+				)
+			},
+			wantOut: []*Session{
+				{
+					ID:            "sess1",
+					LastUpdatedAt: sampleDate,
+					Name:          "Build artifacts",
+					UserID:        1,
+					State:         "completed",
+					ResourceType:  "pull",
+					ResourceID:    2000,
+					PullRequest: &api.PullRequest{
+						ID:             "PR_node",
+						FullDatabaseID: "2000",
+						Number:         42,
+						Title:          "Improve docs",
+						State:          "OPEN",
+						IsDraft:        true,
+						URL:            "https://github.com/OWNER/REPO/pull/42",
+						Body:           "",
+						CreatedAt:      sampleDate,
+						UpdatedAt:      sampleDate,
+						Repository: &api.PRRepository{
+							NameWithOwner: "OWNER/REPO",
+						},
+					},
+					User: &api.GitHubUser{
+						Login:      "octocat",
+						Name:       "Octocat",
+						DatabaseID: 1,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, reg)
+			}
+			defer reg.Verify(t)
+
+			httpClient := &http.Client{Transport: reg}
+
+			cfg := config.NewBlankConfig()
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+			sessions, err := capiClient.ListSessionsForPullRequest(context.Background(), "github.com", "OWNER", "REPO", 42, 10)
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Nil(t, sessions)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOut, sessions)
+		})
+	}
+}
+
+func TestListSessionsForOwnerRequiresOwner(t *testing.T) {
+	client := &CAPIClient{}
+
+	_, err := client.ListSessionsForOwner(context.Background(), "", 10)
+	assert.EqualError(t, err, "missing owner")
+}
+
+func TestListSessionsForOwner(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
+	require.NoError(t, err)
+
+	sessionJSON := heredoc.Docf(`
+		{
+			"sessions": [
+				{
+					"id": "sess1",
+					"name": "Build artifacts",
+					"user_id": 1,
+					"state": "completed",
+					"owner_id": 10,
+					"repo_id": 1000,
+					"resource_type": "pull",
+					"resource_id": 2000,
+					"created_at": "%[1]s"
+				}
+			]
+		}`,
+		sampleDateString,
+	)
+
+	hydrationJSON := heredoc.Docf(`
+		{
+			"data": {
+				"nodes": [
+					{
+						"__typename": "PullRequest",
+						"id": "PR_node",
+						"fullDatabaseId": "2000",
+						"number": 42,
+						"title": "Improve docs",
+						"state": "OPEN",
+						"url": "https://github.com/OWNER/REPO/pull/42",
+						"createdAt": "%[1]s",
+						"updatedAt": "%[1]s",
+						"repository": {
+							"nameWithOwner": "OWNER/REPO"
+						}
+					},
+					{
+						"__typename": "User",
+						"login": "octocat",
+						"databaseId": 1
+					}
+				]
+			}
+		}`,
+		sampleDateString,
+	)
+
+	wantSession := &Session{
+		ID:           "sess1",
+		Name:         "Build artifacts",
+		UserID:       1,
+		State:        "completed",
+		OwnerID:      10,
+		RepoID:       1000,
+		ResourceType: "pull",
+		ResourceID:   2000,
+		CreatedAt:    sampleDate,
+		PullRequest: &api.PullRequest{
+			ID:             "PR_node",
+			FullDatabaseID: "2000",
+			Number:         42,
+			Title:          "Improve docs",
+			State:          "OPEN",
+			URL:            "https://github.com/OWNER/REPO/pull/42",
+			CreatedAt:      sampleDate,
+			UpdatedAt:      sampleDate,
+			Repository:     &api.PRRepository{NameWithOwner: "OWNER/REPO"},
+		},
+		User: &api.GitHubUser{Login: "octocat", DatabaseID: 1},
+	}
+
+	tests := []struct {
+		name      string
+		owner     string
+		limit     int
+		httpStubs func(*testing.T, *httpmock.Registry)
+		wantErr   string
+		wantOut   []*Session
+	}{
+		{
+			name:    "zero limit",
+			owner:   "OWNER",
+			limit:   0,
+			wantOut: nil,
+		},
+		{
+			name:  "owner endpoint available",
+			owner: "OWNER",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions/owner/OWNER", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(sessionJSON),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.StringResponse(hydrationJSON),
+				)
+			},
+			wantOut: []*Session{wantSession},
+		},
+		{
+			name:  "falls back to filtering viewer sessions",
+			owner: "owner",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions/owner/owner", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StatusStringResponse(404, `{}`),
+				)
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(sessionJSON),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.StringResponse(hydrationJSON),
+				)
+			},
+			// owner comparison is case-insensitive
+			wantOut: []*Session{wantSession},
+		},
+		{
+			name:  "fallback filters out other owners",
+			owner: "someone-else",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions/owner/someone-else", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StatusStringResponse(404, `{}`),
+				)
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StringResponse(sessionJSON),
+				)
+				// GraphQL hydration
+				reg.Register(
+					httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+					httpmock.StringResponse(hydrationJSON),
+				)
+			},
+			wantOut: []*Session{},
+		},
+		{
+			name:  "server error",
+			owner: "OWNER",
+			limit: 10,
+			httpStubs: func(t *testing.T, reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.WithHost(
+						httpmock.QueryMatcher("GET", "agents/sessions/owner/OWNER", url.Values{
+							"page_number": {"1"},
+							"page_size":   {"50"},
+						}),
+						"api.githubcopilot.com",
+					),
+					httpmock.StatusStringResponse(500, `{}`),
+				)
+			},
+			wantErr: "failed to list sessions:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			if tt.httpStubs != nil {
+				tt.httpStubs(t, reg)
+			}
+			defer reg.Verify(t)
+
+			httpClient := &http.Client{Transport: reg}
+
+			cfg := config.NewBlankConfig()
+			capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+			sessions, err := capiClient.ListSessionsForOwner(context.Background(), tt.owner, tt.limit)
+
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				require.Nil(t, sessions)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantOut, sessions)
+		})
+	}
+}
+
+func TestListSessionsForRepoRequiresOwnerAndRepo(t *testing.T) {
+	client := &CAPIClient{}
+
+	_, err := client.ListSessionsForRepo(context.Background(), "", "REPO", 10)
+	assert.EqualError(t, err, "missing owner/repo")
+
+	_, err = client.ListSessionsForRepo(context.Background(), "OWNER", "", 10)
+	assert.EqualError(t, err, "missing owner/repo")
+}
+
+func TestListSessionsForRepo(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+
+	sessionJSON := heredoc.Docf(`
+		{
+			"sessions": [
+				{
+					"id": "sess1",
+					"name": "In this repo",
+					"user_id": 1,
+					"state": "completed",
+					"owner_id": 10,
+					"repo_id": 1000,
+					"resource_type": "pull",
+					"resource_id": 2000,
+					"created_at": "%[1]s"
+				},
+				{
+					"id": "sess2",
+					"name": "In a sibling repo",
+					"user_id": 1,
+					"state": "completed",
+					"owner_id": 10,
+					"repo_id": 1001,
+					"resource_type": "pull",
+					"resource_id": 2001,
+					"created_at": "%[1]s"
+				}
+			]
+		}`,
+		sampleDateString,
+	)
+
+	hydrationJSON := heredoc.Docf(`
+		{
+			"data": {
+				"nodes": [
+					{
+						"__typename": "PullRequest",
+						"id": "PR_node1",
+						"fullDatabaseId": "2000",
+						"number": 42,
+						"title": "Improve docs",
+						"state": "OPEN",
+						"url": "https://github.com/OWNER/REPO/pull/42",
+						"createdAt": "%[1]s",
+						"updatedAt": "%[1]s",
+						"repository": {
+							"nameWithOwner": "OWNER/REPO"
+						}
+					},
+					{
+						"__typename": "PullRequest",
+						"id": "PR_node2",
+						"fullDatabaseId": "2001",
+						"number": 7,
+						"title": "Unrelated change",
+						"state": "OPEN",
+						"url": "https://github.com/OWNER/OTHER/pull/7",
+						"createdAt": "%[1]s",
+						"updatedAt": "%[1]s",
+						"repository": {
+							"nameWithOwner": "OWNER/OTHER"
+						}
+					},
+					{
+						"__typename": "User",
+						"login": "octocat",
+						"databaseId": 1
+					}
+				]
+			}
+		}`,
+		sampleDateString,
+	)
+
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions/owner/OWNER", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"50"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StringResponse(sessionJSON),
+	)
+	// GraphQL hydration
+	reg.Register(
+		httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+		httpmock.StringResponse(hydrationJSON),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+	// Sessions in sibling repositories of the same owner are filtered out
+	// using the hydrated pull request data.
+	sessions, err := capiClient.ListSessionsForRepo(context.Background(), "OWNER", "repo", 10)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "sess1", sessions[0].ID)
+	assert.Equal(t, "OWNER/REPO", sessions[0].PullRequest.Repository.NameWithOwner)
+}
+
+func TestHydrationPreservesEventFields(t *testing.T) {
+	sampleDateString := "2025-08-29T00:00:00Z"
+
+	sessionJSON := heredoc.Docf(`
+		{
+			"sessions": [
+				{
+					"id": "sess1",
+					"name": "Webhook-triggered",
+					"user_id": 1,
+					"state": "completed",
+					"owner_id": 10,
+					"repo_id": 1000,
+					"resource_type": "pull",
+					"resource_id": 2000,
+					"event_url": "https://api.github.com/repos/OWNER/REPO/issues/comments/1",
+					"event_type": "issue_comment",
+					"created_at": "%[1]s"
+				}
+			]
+		}`,
+		sampleDateString,
+	)
+
+	hydrationJSON := heredoc.Docf(`
+		{
+			"data": {
+				"nodes": [
+					{
+						"__typename": "PullRequest",
+						"id": "PR_node1",
+						"fullDatabaseId": "2000",
+						"number": 42,
+						"title": "Improve docs",
+						"state": "OPEN",
+						"url": "https://github.com/OWNER/REPO/pull/42",
+						"createdAt": "%[1]s",
+						"updatedAt": "%[1]s",
+						"repository": {
+							"nameWithOwner": "OWNER/REPO"
+						}
+					},
+					{
+						"__typename": "User",
+						"login": "octocat",
+						"databaseId": 1
+					}
+				]
+			}
+		}`,
+		sampleDateString,
+	)
+
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions/owner/OWNER", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"50"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StringResponse(sessionJSON),
+	)
+	reg.Register(
+		httpmock.GraphQL(`query FetchPRsAndUsersForAgentTaskSessions\b`),
+		httpmock.StringResponse(hydrationJSON),
+	)
+	defer reg.Verify(t)
+
+	httpClient := &http.Client{Transport: reg}
+
+	cfg := config.NewBlankConfig()
+	capiClient := NewCAPIClient(httpClient, cfg.Authentication())
+
+	sessions, err := capiClient.ListSessionsForRepo(context.Background(), "OWNER", "REPO", 10)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	// The event fields decoded from the raw session must not be lost when the
+	// session is hydrated with pull request and user data.
+	assert.Equal(t, "issue_comment", sessions[0].EventType)
+	assert.Equal(t, "https://api.github.com/repos/OWNER/REPO/issues/comments/1", sessions[0].EventURL)
+	require.NotNil(t, sessions[0].PullRequest)
+}
+
+func TestListSessionsForRepoZeroLimit(t *testing.T) {
+	client := &CAPIClient{}
+
+	sessions, err := client.ListSessionsForRepo(context.Background(), "OWNER", "REPO", 0)
+	require.NoError(t, err)
+	require.Nil(t, sessions)
+}
+
+func TestGenerateNodeIDs(t *testing.T) {
+	prNodeID, err := generatePullRequestNodeID(1000, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, "PR_kwDNA-jNB9A", prNodeID)
+
+	userNodeID, err := generateUserNodeID(1)
+	require.NoError(t, err)
+	assert.Equal(t, "U_kgAB", userNodeID)
+
+	// Extreme values must encode without panicking.
+	for _, id := range []int64{0, -1, math.MinInt64, math.MaxInt64} {
+		_, err := generatePullRequestNodeID(id, id)
+		assert.NoError(t, err)
+		_, err = generateUserNodeID(id)
+		assert.NoError(t, err)
+	}
+}
+
+func TestSessionIsTerminal(t *testing.T) {
+	tests := []struct {
+		state string
+		want  bool
+	}{
+		{state: SessionStateQueued, want: false},
+		{state: SessionStateInProgress, want: false},
+		{state: SessionStateIdle, want: false},
+		{state: SessionStateWaitingForUser, want: false},
+		{state: SessionStateCompleted, want: true},
+		{state: SessionStateFailed, want: true},
+		{state: SessionStateTimedOut, want: true},
+		{state: SessionStateCancelled, want: true},
+		{state: SessionStateCanceled, want: true},
+		{state: "mystery", want: false},
+		{state: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			s := &Session{State: tt.state}
+			assert.Equal(t, tt.want, s.IsTerminal())
+		})
+	}
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/pmezard/go-difflib/difflib"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/cli/v2/api"
 	"github.com/cli/cli/v2/internal/gh"
 	"github.com/cli/cli/v2/internal/ghrepo"
 	"github.com/cli/cli/v2/internal/prompter"
@@ -28,7 +35,9 @@ type CreateOptions struct {
 	IO         *iostreams.IOStreams
 	BaseRepo   func() (ghrepo.Interface, error)
 	CapiClient func() (capi.CapiClient, error)
+	HttpClient func() (*http.Client, error)
 	Config     func() (gh.Config, error)
+	GitRoot    func() (string, error)
 
 	LogRenderer func() shared.LogRenderer
 	Sleep       func(d time.Duration)
@@ -38,7 +47,17 @@ type CreateOptions struct {
 	BaseBranch           string
 	Prompter             prompter.Prompter
 	ProblemStatementFile string
+	PullRequest          int
+	Agent                string
+	AddRepos             []string
+	Vars                 []string
+	Draft                *bool
 	Follow               bool
+	ShowChanges          bool
+	Yes                  bool
+	NoTemplate           bool
+
+	Exporter cmdutil.Exporter
 }
 
 func defaultLogRenderer() shared.LogRenderer {
@@ -47,14 +66,20 @@ func defaultLogRenderer() shared.LogRenderer {
 
 func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Command {
 	opts := &CreateOptions{
-		IO:          f.IOStreams,
-		CapiClient:  shared.CapiClientFunc(f),
-		Config:      f.Config,
+		IO:         f.IOStreams,
+		CapiClient: shared.CapiClientFunc(f),
+		HttpClient: f.HttpClient,
+		Config:     f.Config,
+		GitRoot: func() (string, error) {
+			return f.GitClient.ToplevelDir(context.Background())
+		},
 		Prompter:    f.Prompter,
 		LogRenderer: defaultLogRenderer,
 		Sleep:       time.Sleep,
 	}
 
+	var draft, noDraft bool
+
 	cmd := &cobra.Command{
 		Use:   "create [<task description>] [flags]",
 		Short: "Create an agent task (preview)",
@@ -67,13 +92,59 @@ func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Co
 				return err
 			}
 
+			if opts.ShowChanges && opts.ProblemStatementFile == "" {
+				return cmdutil.FlagErrorf("-F is required when providing --show-changes")
+			}
+
+			for _, repoName := range opts.AddRepos {
+				if _, err := ghrepo.FromFullName(repoName); err != nil {
+					return cmdutil.FlagErrorf("invalid value for --add-repo: %v", err)
+				}
+			}
+
+			for _, v := range opts.Vars {
+				if key, _, ok := strings.Cut(v, "="); !ok || key == "" {
+					return cmdutil.FlagErrorf("invalid value for --var: expected the \"key=value\" format, got %q", v)
+				}
+			}
+
+			// --draft/--no-draft collapse into a single tri-state: nil leaves
+			// the created pull request's draft state to the server.
+			if cmd.Flags().Changed("draft") {
+				opts.Draft = &draft
+			} else if cmd.Flags().Changed("no-draft") {
+				d := !noDraft
+				opts.Draft = &d
+			}
+
+			if cmd.Flags().Changed("pull-request") {
+				if opts.PullRequest < 1 {
+					return cmdutil.FlagErrorf("invalid pull request number: %v", opts.PullRequest)
+				}
+				if opts.BaseBranch != "" {
+					return cmdutil.FlagErrorf("--base cannot be used with --pull-request")
+				}
+				// Follow-up jobs run on the session's existing agent.
+				if opts.Agent != "" {
+					return cmdutil.FlagErrorf("--agent cannot be used with --pull-request")
+				}
+			}
+
+			// --follow streams rendered logs, which has no JSON representation.
+			if opts.Exporter != nil && opts.Follow {
+				return cmdutil.FlagErrorf("--json cannot be used with --follow")
+			}
+
 			// Populate ProblemStatement from arg
 			if len(args) > 0 {
 				opts.ProblemStatement = args[0]
 				if strings.TrimSpace(opts.ProblemStatement) == "" {
 					return cmdutil.FlagErrorf("task description cannot be empty")
 				}
-			} else if opts.ProblemStatementFile == "" && !opts.IO.CanPrompt() {
+			} else if opts.ProblemStatementFile == "" && !opts.IO.CanPrompt() && opts.IO.IsStdinTTY() {
+				// A piped stdin feeds the task description instead (see
+				// createRun); only a non-interactive run with nothing on stdin
+				// has no description source at all.
 				return cmdutil.FlagErrorf("a task description or -F is required when running non-interactively")
 			}
 
@@ -95,6 +166,9 @@ func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Co
 			# Create a task with problem statement from stdin
 			$ echo "build me a new app" | gh agent-task create -F -
 
+			# Pipe the problem statement directly, without -F
+			$ echo "build me a new app" | gh agent-task create
+
 			# Create a task with an editor
 			$ gh agent-task create
 
@@ -103,6 +177,12 @@ func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Co
 
 			# Select a different base branch for the PR
 			$ gh agent-task create "fix errors" --base branch
+
+			# Ask the agent to continue work on an existing PR
+			$ gh agent-task create "address the review comments" --pull-request 123
+
+			# Substitute placeholders in a reusable task description file
+			$ gh agent-task create -F task-template.md --var service=payments
 		`),
 	}
 
@@ -110,7 +190,18 @@ func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Co
 
 	cmd.Flags().StringVarP(&opts.ProblemStatementFile, "from-file", "F", "", "Read task description from `file` (use \"-\" to read from standard input)")
 	cmd.Flags().StringVarP(&opts.BaseBranch, "base", "b", "", "Base branch for the pull request (use default branch if not provided)")
+	cmd.Flags().IntVar(&opts.PullRequest, "pull-request", 0, "Queue a follow-up task on the agent session for the given pull request `number` in the base repo")
+	cmd.Flags().StringVar(&opts.Agent, "agent", "", "The `name` of the agent to handle the task (default: the account's default agent)")
+	cmd.Flags().StringArrayVar(&opts.AddRepos, "add-repo", nil, "Additional repository in `OWNER/REPO` format the agent may need as context (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.Vars, "var", nil, "Substitute {{.key}} placeholders in the task description with the given `key=value` (can be repeated)")
+	cmd.Flags().BoolVar(&draft, "draft", false, "Request that the agent open its pull request as a draft (best effort)")
+	cmd.Flags().BoolVar(&noDraft, "no-draft", false, "Request that the agent open its pull request ready for review (best effort)")
+	cmd.MarkFlagsMutuallyExclusive("draft", "no-draft")
 	cmd.Flags().BoolVar(&opts.Follow, "follow", false, "Follow agent session logs")
+	cmd.Flags().BoolVar(&opts.ShowChanges, "show-changes", false, "Edit the task description from -F and show a diff of your changes before submitting")
+	cmd.Flags().BoolVarP(&opts.Yes, "yes", "y", false, "Skip the submission confirmation prompt")
+	cmd.Flags().BoolVar(&opts.NoTemplate, "no-template", false, "Start the editor empty instead of from the repository's agent task template")
+	cmdutil.AddJSONFlags(cmd, &opts.Exporter, createdTaskFields)
 
 	return cmd
 }
@@ -118,9 +209,21 @@ func NewCmdCreate(f *cmdutil.Factory, runF func(*CreateOptions) error) *cobra.Co
 func createRun(opts *CreateOptions) error {
 	repo, err := opts.BaseRepo()
 	if err != nil || repo == nil {
-		// Not printing the error that came back from BaseRepo() here because we want
-		// something clear, human friendly, and actionable.
-		return fmt.Errorf("a repository is required; re-run in a repository or supply one with --repo owner/name")
+		if !opts.IO.CanPrompt() {
+			// Not printing the error that came back from BaseRepo() here because we want
+			// something clear, human friendly, and actionable.
+			return fmt.Errorf("a repository is required; re-run in a repository or supply one with --repo owner/name")
+		}
+
+		// Interactively, ask for a repository instead of bailing out.
+		input, err := opts.Prompter.Input("Which repository should the agent work in? (OWNER/REPO)", "")
+		if err != nil {
+			return err
+		}
+		repo, err = ghrepo.FromFullName(strings.TrimSpace(input))
+		if err != nil {
+			return fmt.Errorf("invalid repository: %v", err)
+		}
 	}
 
 	if opts.ProblemStatement == "" {
@@ -135,9 +238,41 @@ func createRun(opts *CreateOptions) error {
 				return errors.New("task description file cannot be empty")
 			}
 
+			if opts.ShowChanges && opts.IO.CanPrompt() {
+				edited, err := opts.Prompter.MarkdownEditor("Enter the task description", trimmed, false)
+				if err != nil {
+					return err
+				}
+
+				editedTrimmed := strings.TrimSpace(edited)
+				if editedTrimmed == "" {
+					return errors.New("a task description is required")
+				}
+
+				if editedTrimmed != trimmed {
+					printStatementDiff(opts.IO, filepath.Base(opts.ProblemStatementFile), trimmed, editedTrimmed)
+				}
+
+				trimmed = editedTrimmed
+			}
+
+			opts.ProblemStatement = trimmed
+		} else if !opts.IO.IsStdinTTY() {
+			// No description argument and no -F, but stdin is a pipe: read the
+			// task description from it the way -F - would.
+			piped, err := io.ReadAll(opts.IO.In)
+			if err != nil {
+				return fmt.Errorf("could not read task description from standard input: %w", err)
+			}
+
+			trimmed := strings.TrimSpace(string(piped))
+			if trimmed == "" {
+				return errors.New("task description from standard input cannot be empty")
+			}
+
 			opts.ProblemStatement = trimmed
 		} else {
-			desc, err := opts.Prompter.MarkdownEditor("Enter the task description", opts.ProblemStatement, false)
+			desc, err := opts.Prompter.MarkdownEditor("Enter the task description", taskTemplate(opts), false)
 			if err != nil {
 				return err
 			}
@@ -151,16 +286,52 @@ func createRun(opts *CreateOptions) error {
 		}
 	}
 
+	// Substitution is opt-in: without --var, literal braces in the task
+	// description pass through untouched.
+	if len(opts.Vars) > 0 {
+		expanded, err := expandVariables(opts.ProblemStatement, opts.Vars, repo, opts.BaseBranch)
+		if err != nil {
+			return err
+		}
+		opts.ProblemStatement = expanded
+	}
+
+	// Interactive runs confirm before submitting. --yes skips the prompt so
+	// semi-scripted terminals can create tasks without interaction; the
+	// description prompt above still applies when no statement was provided.
+	if !opts.Yes && opts.IO.CanPrompt() {
+		confirmed, err := opts.Prompter.Confirm("Submit agent task", true)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return cmdutil.CancelError
+		}
+	}
+
 	client, err := opts.CapiClient()
 	if err != nil {
 		return err
 	}
 
 	ctx := context.Background()
-	opts.IO.StartProgressIndicatorWithLabel(fmt.Sprintf("Creating agent task in %s/%s...", repo.RepoOwner(), repo.RepoName()))
+
+	if opts.PullRequest > 0 {
+		return createFollowUp(ctx, opts, client, repo)
+	}
+
+	var agentID int64
+	if opts.Agent != "" {
+		agentID, err = resolveAgentID(ctx, client, opts.Agent)
+		if err != nil {
+			return err
+		}
+	}
+
+	opts.IO.StartProgressIndicatorWithLabel(createTaskProgressLabel(opts, repo))
 	defer opts.IO.StopProgressIndicator()
 
-	job, err := client.CreateJob(ctx, repo.RepoOwner(), repo.RepoName(), opts.ProblemStatement, opts.BaseBranch)
+	job, err := client.CreateJob(ctx, repo.RepoOwner(), repo.RepoName(), opts.ProblemStatement, opts.BaseBranch, opts.Draft, opts.AddRepos, agentID)
 	if err != nil {
 		return err
 	}
@@ -169,6 +340,32 @@ func createRun(opts *CreateOptions) error {
 		return followLogs(opts, client, job.SessionID)
 	}
 
+	if opts.Exporter != nil {
+		jobWithPR, err := fetchJobPullRequest(ctx, client, repo, job, opts.BackOff)
+		opts.IO.StopProgressIndicator()
+		if err != nil {
+			// Like the human output below, a failed poll is not fatal: the job
+			// was created, so emit what is known and note the error on stderr.
+			fmt.Fprintf(opts.IO.ErrOut, "%v\n", err)
+		}
+
+		task := &createdTask{
+			JobID:     job.ID,
+			SessionID: job.SessionID,
+			Repo:      ghrepo.FullName(repo),
+		}
+		if jobWithPR != nil && jobWithPR.PullRequest != nil && jobWithPR.PullRequest.Number > 0 {
+			if jobWithPR.SessionID != "" {
+				task.SessionID = jobWithPR.SessionID
+			}
+			task.PullRequest = &createdTaskPullRequest{
+				Number: jobWithPR.PullRequest.Number,
+				URL:    fmt.Sprintf("https://github.com/%s/%s/pull/%d", url.PathEscape(repo.RepoOwner()), url.PathEscape(repo.RepoName()), jobWithPR.PullRequest.Number),
+			}
+		}
+		return opts.Exporter.Write(opts.IO, task)
+	}
+
 	sessionURL, err := fetchJobSessionURL(ctx, client, repo, job, opts.BackOff)
 	opts.IO.StopProgressIndicator()
 
@@ -181,12 +378,205 @@ func createRun(opts *CreateOptions) error {
 			// want to print it.
 			fmt.Fprintf(opts.IO.ErrOut, "%v\n", err)
 		}
-		fmt.Fprintf(opts.IO.Out, "job %s queued. View progress: %s\n", job.ID, capi.AgentsHomeURL)
+		fmt.Fprintf(opts.IO.Out, "job %s queued. View progress: %s\n", job.ID, capi.AgentsHomeURL())
+	}
+
+	return nil
+}
+
+// resolveAgentID maps an agent name given via --agent to its ID using
+// ListAgents. Matching is case-insensitive; an unknown name lists the
+// available agents in the error so the flag can be corrected.
+func resolveAgentID(ctx context.Context, client capi.CapiClient, name string) (int64, error) {
+	agents, err := client.ListAgents(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	names := make([]string, 0, len(agents))
+	for _, a := range agents {
+		if strings.EqualFold(a.Name, name) {
+			return a.ID, nil
+		}
+		names = append(names, a.Name)
 	}
 
+	if len(names) == 0 {
+		return 0, fmt.Errorf("no agent named %q found", name)
+	}
+	return 0, fmt.Errorf("no agent named %q found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// createFollowUp queues a follow-up task on the agent session associated with
+// an existing pull request instead of creating a brand-new job. The pull
+// request number is resolved against the base repo, which also validates that
+// the number belongs to it.
+func createFollowUp(ctx context.Context, opts *CreateOptions, client capi.CapiClient, repo ghrepo.Interface) error {
+	opts.IO.StartProgressIndicatorWithLabel(fmt.Sprintf("Creating follow-up task for %s/%s#%d...", repo.RepoOwner(), repo.RepoName(), opts.PullRequest))
+	defer opts.IO.StopProgressIndicator()
+
+	prID, prURL, err := client.GetPullRequestDatabaseID(ctx, repo.RepoHost(), repo.RepoOwner(), repo.RepoName(), opts.PullRequest)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull request: %w", err)
+	}
+
+	sessions, err := client.ListSessionsByResourceID(ctx, "pull", prID, 1)
+	if err != nil {
+		return fmt.Errorf("failed to list sessions for pull request: %w", err)
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no agent session found for pull request #%d", opts.PullRequest)
+	}
+
+	// Sessions are returned newest first, so the follow-up targets the most
+	// recently updated session for the pull request.
+	session := sessions[0]
+	job, err := client.FollowUpJob(ctx, repo.RepoOwner(), repo.RepoName(), session.ID, opts.ProblemStatement)
+	if err != nil {
+		return err
+	}
+
+	sessionID := job.SessionID
+	if sessionID == "" {
+		sessionID = session.ID
+	}
+
+	if opts.Follow {
+		return followLogs(opts, client, sessionID)
+	}
+
+	opts.IO.StopProgressIndicator()
+
+	if opts.Exporter != nil {
+		return opts.Exporter.Write(opts.IO, &createdTask{
+			JobID:     job.ID,
+			SessionID: sessionID,
+			Repo:      ghrepo.FullName(repo),
+			PullRequest: &createdTaskPullRequest{
+				Number: opts.PullRequest,
+				URL:    prURL,
+			},
+		})
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s/agent-sessions/%s\n", prURL, url.PathEscape(sessionID))
 	return nil
 }
 
+// createdTaskFields is the set of fields --json can export once the task has
+// been created.
+var createdTaskFields = []string{"jobId", "sessionId", "pullRequest", "repo"}
+
+// createdTask is the machine-readable summary of a newly created task,
+// emitted by --json so pipelines can capture the session ID for a later view.
+// The pull request entry is nil when polling gave up before the agent opened
+// one.
+type createdTask struct {
+	JobID       string                  `json:"jobId"`
+	SessionID   string                  `json:"sessionId"`
+	PullRequest *createdTaskPullRequest `json:"pullRequest"`
+	Repo        string                  `json:"repo"`
+}
+
+type createdTaskPullRequest struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+func (t *createdTask) ExportData(fields []string) map[string]interface{} {
+	return cmdutil.StructExportData(t, fields)
+}
+
+// taskTemplateFile is the repository-provided template for new agent tasks,
+// relative to the root of the local working copy.
+const taskTemplateFile = ".github/agent-task-template.md"
+
+// taskTemplate returns the contents of the repository's agent task template,
+// used to pre-populate the editor when no description was provided. Like
+// issue and pull request templates, the lookup is best effort: outside a git
+// repository, or when no template file exists, the editor starts empty.
+func taskTemplate(opts *CreateOptions) string {
+	if opts.NoTemplate || opts.GitRoot == nil {
+		return ""
+	}
+	root, err := opts.GitRoot()
+	if err != nil {
+		return ""
+	}
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(taskTemplateFile)))
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
+
+// createTaskProgressLabel builds the label shown while the job is created,
+// including the branch the task will target. When --base was not provided the
+// repository's default branch is looked up for display only — the create
+// payload keeps the empty value so the server still picks its own default,
+// and a failed lookup just drops the branch from the label.
+func createTaskProgressLabel(opts *CreateOptions, repo ghrepo.Interface) string {
+	baseBranch := opts.BaseBranch
+	if baseBranch == "" && opts.HttpClient != nil {
+		if httpClient, err := opts.HttpClient(); err == nil {
+			if branch, err := api.RepoDefaultBranch(api.NewClientFromHTTP(httpClient), repo); err == nil {
+				baseBranch = branch
+			}
+		}
+	}
+
+	if baseBranch == "" {
+		return fmt.Sprintf("Creating agent task in %s/%s...", repo.RepoOwner(), repo.RepoName())
+	}
+	return fmt.Sprintf("Creating agent task in %s/%s (base: %s)...", repo.RepoOwner(), repo.RepoName(), baseBranch)
+}
+
+// expandVariables renders the task description as a text template against the
+// --var key=value pairs plus the built-in Repo and BaseBranch variables, so
+// reusable description files can carry {{.key}} placeholders. Referencing an
+// undefined variable is an error rather than silently submitting the raw
+// placeholder.
+func expandVariables(statement string, vars []string, repo ghrepo.Interface, baseBranch string) (string, error) {
+	data := map[string]string{
+		"Repo":       ghrepo.FullName(repo),
+		"BaseBranch": baseBranch,
+	}
+	for _, v := range vars {
+		key, value, _ := strings.Cut(v, "=")
+		data[key] = value
+	}
+
+	tmpl, err := template.New("task description").Option("missingkey=error").Parse(statement)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse task description template: %w", err)
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to expand task description: %w", err)
+	}
+	return b.String(), nil
+}
+
+// printStatementDiff writes a unified diff between the original file-based
+// statement and the edited statement so the user can see what changed before
+// submitting.
+func printStatementDiff(io *iostreams.IOStreams, filename, original, edited string) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(original),
+		B:        difflib.SplitLines(edited),
+		FromFile: filename,
+		ToFile:   "edited",
+		Context:  3,
+	})
+	if err != nil || diff == "" {
+		return
+	}
+
+	fmt.Fprintln(io.ErrOut, "Changes to the task description:")
+	fmt.Fprint(io.ErrOut, diff)
+}
+
 func agentSessionWebURL(repo ghrepo.Interface, j *capi.Job) string {
 	if j.PullRequest == nil {
 		return ""
@@ -200,10 +590,21 @@ func agentSessionWebURL(repo ghrepo.Interface, j *capi.Job) string {
 // fetchJobSessionURL tries to return the agent session URL for a job. If the pull
 // request is not yet available, ("", nil) is returned.
 func fetchJobSessionURL(ctx context.Context, client capi.CapiClient, repo ghrepo.Interface, job *capi.Job, bo backoff.BackOff) (string, error) {
+	jobWithPR, err := fetchJobPullRequest(ctx, client, repo, job, bo)
+	if jobWithPR != nil {
+		return agentSessionWebURL(repo, jobWithPR), nil
+	}
+	return "", err
+}
+
+// fetchJobPullRequest returns the job with its pull request data populated,
+// polling until the PR appears or the backoff gives up. On timeout it returns
+// (nil, nil).
+func fetchJobPullRequest(ctx context.Context, client capi.CapiClient, repo ghrepo.Interface, job *capi.Job, bo backoff.BackOff) (*capi.Job, error) {
 	if job.PullRequest != nil && job.PullRequest.Number > 0 {
-		// Return the agent session URL if we happen to get it.
+		// Return the job as-is if the PR happens to be there already.
 		// Right now, this never happens.
-		return agentSessionWebURL(repo, job), nil
+		return job, nil
 	}
 
 	if bo == nil {
@@ -215,16 +616,13 @@ func fetchJobSessionURL(ctx context.Context, client capi.CapiClient, repo ghrepo
 		)
 	}
 
-	jobWithPR, err := fetchJobWithBackoff(ctx, client, repo, job.ID, bo)
-	if jobWithPR != nil {
-		return agentSessionWebURL(repo, jobWithPR), nil
-	}
-	return "", err
+	return fetchJobWithBackoff(ctx, client, repo, job.ID, bo)
 }
 
 // fetchJobWithBackoff polls the job resource until a PR number is present or the overall
 // timeout elapses. It returns the updated Job on success, (nil, nil) on timeout,
-// and (nil, error) only for non-retryable failures.
+// and (nil, error) for permanent failures or when transient errors outlast the
+// backoff budget.
 func fetchJobWithBackoff(ctx context.Context, client capi.CapiClient, repo ghrepo.Interface, jobID string, bo backoff.BackOff) (*capi.Job, error) {
 	// sentinel error to signal timeout
 	var errPRNotReady = errors.New("job not ready")
@@ -233,7 +631,10 @@ func fetchJobWithBackoff(ctx context.Context, client capi.CapiClient, repo ghrep
 	retryErr := backoff.Retry(func() error {
 		j, err := client.GetJob(ctx, repo.RepoOwner(), repo.RepoName(), jobID)
 		if err != nil {
-			// Do not retry on GetJob errors; surface immediately.
+			if retryableGetJobError(err) {
+				// Transient failures keep polling within the backoff budget.
+				return err
+			}
 			return backoff.Permanent(err)
 		}
 		if j.PullRequest != nil && j.PullRequest.Number > 0 {
@@ -253,6 +654,19 @@ func fetchJobWithBackoff(ctx context.Context, client capi.CapiClient, repo ghrep
 	return result, nil
 }
 
+// retryableGetJobError reports whether a GetJob failure is worth retrying
+// while polling for the pull request: server errors and rate limiting are
+// transient, while other HTTP errors (such as 404s) will not resolve on their
+// own. Non-HTTP errors are transport-level failures and may succeed on a
+// later attempt.
+func retryableGetJobError(err error) bool {
+	var statusErr *capi.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
+
 func followLogs(opts *CreateOptions, capiClient capi.CapiClient, sessionID string) error {
 	if err := opts.IO.StartPager(); err == nil {
 		defer opts.IO.StopPager()
@@ -264,17 +678,13 @@ func followLogs(opts *CreateOptions, capiClient capi.CapiClient, sessionID strin
 	renderer := opts.LogRenderer()
 
 	var called bool
-	fetcher := func() ([]byte, error) {
+	fetcher := func(offset int64) ([]byte, bool, error) {
 		if called {
 			opts.Sleep(defaultLogPollInterval)
 		}
 		called = true
-		raw, err := capiClient.GetSessionLogs(ctx, sessionID)
-		if err != nil {
-			return nil, err
-		}
-		return raw, nil
+		return capiClient.GetSessionLogsFrom(ctx, sessionID, offset)
 	}
 
-	return renderer.Follow(fetcher, opts.IO.Out, opts.IO)
+	return renderer.Follow(fetcher, opts.IO.Out, opts.IO, nil)
 }
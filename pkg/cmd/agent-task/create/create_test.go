@@ -1,9 +1,11 @@
 package create
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,12 +18,15 @@ import (
 	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
 	"github.com/cli/cli/v2/pkg/cmd/agent-task/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/httpmock"
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func boolPtr(b bool) *bool { return &b }
+
 func TestNewCmdCreate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -84,6 +89,91 @@ func TestNewCmdCreate(t *testing.T) {
 				Follow:               true,
 			},
 		},
+		{
+			name: "with --pull-request",
+			args: "'task description' --pull-request 123",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				PullRequest:      123,
+			},
+		},
+		{
+			name:    "invalid --pull-request",
+			args:    "'task description' --pull-request 0",
+			wantErr: "invalid pull request number: 0",
+		},
+		{
+			name:    "mutually exclusive --pull-request and --base",
+			args:    "'task description' --pull-request 123 -b feature",
+			wantErr: "--base cannot be used with --pull-request",
+		},
+		{
+			name: "with --draft",
+			args: "'task description' --draft",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				Draft:            boolPtr(true),
+			},
+		},
+		{
+			name: "with --no-draft",
+			args: "'task description' --no-draft",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				Draft:            boolPtr(false),
+			},
+		},
+		{
+			name: "neither --draft nor --no-draft leaves draft state unset",
+			args: "'task description'",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+			},
+		},
+		{
+			name: "with repeated --add-repo",
+			args: "'task description' --add-repo OWNER/REPO --add-repo OTHER/REPO2",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				AddRepos:         []string{"OWNER/REPO", "OTHER/REPO2"},
+			},
+		},
+		{
+			name:    "invalid --add-repo",
+			args:    "'task description' --add-repo not-a-repo",
+			wantErr: `invalid value for --add-repo: expected the "[HOST/]OWNER/REPO" format, got "not-a-repo"`,
+		},
+		{
+			name: "with --yes",
+			args: "'task description' --yes",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				Yes:              true,
+			},
+		},
+		{
+			name: "with repeated --var",
+			args: "'task description' --var service=payments --var owner=octocat",
+			wantOpts: &CreateOptions{
+				ProblemStatement: "task description",
+				Vars:             []string{"service=payments", "owner=octocat"},
+			},
+		},
+		{
+			name:    "invalid --var",
+			args:    "'task description' --var no-equals-sign",
+			wantErr: `invalid value for --var: expected the "key=value" format, got "no-equals-sign"`,
+		},
+		{
+			name:    "mutually exclusive --draft and --no-draft",
+			args:    "'task description' --draft --no-draft",
+			wantErr: "if any flags in the group [draft no-draft] are set none of the others can be; [draft no-draft] were all set",
+		},
+		{
+			name:    "mutually exclusive --json and --follow",
+			args:    "'task description' --json jobId --follow",
+			wantErr: "--json cannot be used with --follow",
+		},
 	}
 
 	for _, tt := range tests {
@@ -120,6 +210,11 @@ func TestNewCmdCreate(t *testing.T) {
 				require.Equal(t, tt.wantOpts.ProblemStatement, gotOpts.ProblemStatement)
 				require.Equal(t, tt.wantOpts.ProblemStatementFile, gotOpts.ProblemStatementFile)
 				require.Equal(t, tt.wantOpts.BaseBranch, gotOpts.BaseBranch)
+				require.Equal(t, tt.wantOpts.PullRequest, gotOpts.PullRequest)
+				require.Equal(t, tt.wantOpts.Draft, gotOpts.Draft)
+				require.Equal(t, tt.wantOpts.AddRepos, gotOpts.AddRepos)
+				require.Equal(t, tt.wantOpts.Vars, gotOpts.Vars)
+				require.Equal(t, tt.wantOpts.Yes, gotOpts.Yes)
 			}
 		})
 	}
@@ -132,6 +227,10 @@ func Test_createRun(t *testing.T) {
 	require.NoError(t, os.WriteFile(taskDescFile, []byte("task description from file"), 0600))
 	require.NoError(t, os.WriteFile(emptyTaskDescFile, []byte("  \n\n"), 0600))
 
+	templateRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(templateRoot, ".github"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templateRoot, ".github", "agent-task-template.md"), []byte("## Goal\n"), 0600))
+
 	sampleDateString := "2025-08-29T00:00:00Z"
 	sampleDate, err := time.Parse(time.RFC3339, sampleDateString)
 	require.NoError(t, err)
@@ -165,6 +264,7 @@ func Test_createRun(t *testing.T) {
 		name             string
 		isTTY            bool
 		opts             *CreateOptions // input options (IO & BackOff set later)
+		stdin            string
 		capiStubs        func(*testing.T, *capi.CapiClientMock)
 		logRendererStubs func(*testing.T, *shared.LogRendererMock)
 		wantStdout       string
@@ -178,9 +278,16 @@ func Test_createRun(t *testing.T) {
 			opts: &CreateOptions{
 				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
 				ProblemStatement: "task description from arg",
+				Prompter: &prompter.PrompterMock{
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						require.Equal(t, "Submit agent task", prompt)
+						require.True(t, defaultValue)
+						return true, nil
+					},
+				},
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "task description from arg", problemStatement)
@@ -196,7 +303,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "task description from arg",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "task description from arg", problemStatement)
@@ -205,6 +312,65 @@ func Test_createRun(t *testing.T) {
 			},
 			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
 		},
+		{
+			name: "non-interactive, --agent resolves the named agent",
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "task description from arg",
+				Agent:            "padawan",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListAgentsFunc = func(ctx context.Context) ([]*capi.Agent, error) {
+					return []*capi.Agent{
+						{ID: 1, Name: "Copilot"},
+						{ID: 2, Name: "Padawan"},
+					}, nil
+				}
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, int64(2), agentID)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name: "non-interactive, --agent names an unknown agent",
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "task description from arg",
+				Agent:            "bogus",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListAgentsFunc = func(ctx context.Context) ([]*capi.Agent, error) {
+					return []*capi.Agent{
+						{ID: 1, Name: "Copilot"},
+					}, nil
+				}
+			},
+			wantErr: `no agent named "bogus" found (available: Copilot)`,
+		},
+		{
+			name: "non-interactive, problem statement piped on stdin without -F",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+			},
+			stdin: "task description from pipe\n",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "task description from pipe", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name: "non-interactive, empty pipe returns error",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+			},
+			stdin:   "  \n",
+			wantErr: "task description from standard input cannot be empty",
+		},
 		{
 			name:  "interactive, problem statement from file",
 			isTTY: true,
@@ -212,9 +378,14 @@ func Test_createRun(t *testing.T) {
 				BaseRepo:             func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
 				ProblemStatement:     "",
 				ProblemStatementFile: taskDescFile,
+				Prompter: &prompter.PrompterMock{
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "task description from file", problemStatement)
@@ -231,7 +402,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatementFile: taskDescFile,
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "task description from file", problemStatement)
@@ -240,6 +411,103 @@ func Test_createRun(t *testing.T) {
 			},
 			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
 		},
+		{
+			name: "--var substitutes placeholders including built-in variables",
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "Update {{.service}} in {{.Repo}} against {{.BaseBranch}}",
+				BaseBranch:       "main",
+				Vars:             []string{"service=payments"},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "Update payments in OWNER/REPO against main", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name: "--var with undefined placeholder returns error",
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "Fix {{.missing}}",
+				Vars:             []string{"service=payments"},
+			},
+			wantErr: `failed to expand task description: template: task description:1:6: executing "task description" at <.missing>: map has no entry for key "missing"`,
+		},
+		{
+			name: "without --var literal braces are left untouched",
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "Document the {{.placeholder}} syntax",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "Document the {{.placeholder}} syntax", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, problem statement from file with --show-changes, edited",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo:             func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatementFile: taskDescFile,
+				ShowChanges:          true,
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						require.Equal(t, "Enter the task description", prompt)
+						require.Equal(t, "task description from file", defaultValue)
+						return "task description from editor", nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "task description from editor", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+			wantStdErr: heredoc.Doc(`
+				Changes to the task description:
+				--- task-description.md
+				+++ edited
+				@@ -1 +1 @@
+				-task description from file
+				+task description from editor
+			`),
+		},
+		{
+			name:  "interactive, problem statement from file with --show-changes, unedited",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo:             func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatementFile: taskDescFile,
+				ShowChanges:          true,
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						return defaultValue, nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "task description from file", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
 		{
 			name:  "interactive, problem statement from prompt/editor",
 			isTTY: true,
@@ -252,16 +520,96 @@ func Test_createRun(t *testing.T) {
 						require.Equal(t, "Enter the task description", prompt)
 						return "From editor", nil
 					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
 				},
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "From editor", problemStatement)
 					return &createdJobSuccessWithPR, nil
 				}
 			},
 			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
 		},
+		{
+			name:  "interactive, editor pre-populated from repo template",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				GitRoot: func() (string, error) { return templateRoot, nil },
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						require.Equal(t, "## Goal\n", defaultValue)
+						return "From editor", nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "From editor", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, --no-template starts the editor empty",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				GitRoot:    func() (string, error) { return templateRoot, nil },
+				NoTemplate: true,
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						require.Empty(t, defaultValue)
+						return "From editor", nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, absent template starts the editor empty",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				GitRoot: func() (string, error) { return tmpDir, nil },
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						require.Empty(t, defaultValue)
+						return "From editor", nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
 		{
 			name:  "interactive, empty task description from editor returns error",
 			isTTY: true,
@@ -277,6 +625,57 @@ func Test_createRun(t *testing.T) {
 			},
 			wantErr: "a task description is required",
 		},
+		{
+			name:  "interactive, --yes with inline statement proceeds with no prompts",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "task description from arg",
+				Yes:              true,
+				// No Prompter: any prompt attempt would panic the test.
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "task description from arg", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, --yes still prompts for a missing statement",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				Yes:      true,
+				Prompter: &prompter.PrompterMock{
+					MarkdownEditorFunc: func(prompt, defaultValue string, blankAllowed bool) (string, error) {
+						return "From editor", nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "From editor", problemStatement)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, declining confirmation cancels the task",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo:         func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil },
+				ProblemStatement: "task description from arg",
+				Prompter: &prompter.PrompterMock{
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			wantErrIs: cmdutil.CancelError,
+		},
 		{
 			name: "missing repo returns error",
 			opts: &CreateOptions{
@@ -285,6 +684,50 @@ func Test_createRun(t *testing.T) {
 				}},
 			wantErr: "a repository is required; re-run in a repository or supply one with --repo owner/name",
 		},
+		{
+			name:  "interactive, missing repo prompts for one",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return nil, nil
+				},
+				ProblemStatement: "task description",
+				Prompter: &prompter.PrompterMock{
+					InputFunc: func(prompt, defaultValue string) (string, error) {
+						require.Equal(t, "Which repository should the agent work in? (OWNER/REPO)", prompt)
+						require.Empty(t, defaultValue)
+						return "OWNER/REPO", nil
+					},
+					ConfirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					require.Equal(t, "OWNER", owner)
+					require.Equal(t, "REPO", repo)
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name:  "interactive, missing repo with invalid answer returns error",
+			isTTY: true,
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return nil, nil
+				},
+				ProblemStatement: "task description",
+				Prompter: &prompter.PrompterMock{
+					InputFunc: func(prompt, defaultValue string) (string, error) {
+						return "not-a-repo", nil
+					},
+				},
+			},
+			wantErr: `invalid repository: expected the "[HOST/]OWNER/REPO" format, got "not-a-repo"`,
+		},
 		{
 			name: "problem statement loaded from arg non-interactively doesn't prompt or return error",
 			opts: &CreateOptions{
@@ -292,7 +735,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "task description",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "task description", problemStatement)
@@ -309,7 +752,7 @@ func Test_createRun(t *testing.T) {
 				BaseBranch:       "feature",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -334,7 +777,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "Do the thing",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -353,7 +796,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "Do the thing",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -367,6 +810,53 @@ func Test_createRun(t *testing.T) {
 			wantStdErr: "some error\n",
 			wantStdout: "job job123 queued. View progress: https://github.com/copilot/agents\n",
 		},
+		{
+			name: "transient server error mid-poll is retried",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				ProblemStatement: "Do the thing",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &createdJobSuccess, nil
+				}
+
+				count := 0
+				m.GetJobFunc = func(ctx context.Context, owner, repo, jobID string) (*capi.Job, error) {
+					if count++; count == 1 {
+						return nil, &capi.HTTPStatusError{StatusCode: 500, Message: "failed to get job: 500 Internal Server Error"}
+					}
+					return &createdJobSuccessWithPR, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name: "permanent client error mid-poll aborts polling",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				ProblemStatement: "Do the thing",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &createdJobSuccess, nil
+				}
+
+				count := 0
+				m.GetJobFunc = func(ctx context.Context, owner, repo, jobID string) (*capi.Job, error) {
+					if count++; count > 1 {
+						require.FailNow(t, "permanent errors must not be retried")
+					}
+					return nil, &capi.HTTPStatusError{StatusCode: 404, Message: "failed to get job: 404 Not Found"}
+				}
+			},
+			wantStdErr: "failed to get job: 404 Not Found\n",
+			wantStdout: "job job123 queued. View progress: https://github.com/copilot/agents\n",
+		},
 		{
 			name: "success with immediate PR",
 			opts: &CreateOptions{
@@ -376,7 +866,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "Do the thing",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -395,7 +885,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "Do the thing",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -420,7 +910,7 @@ func Test_createRun(t *testing.T) {
 				ProblemStatement: "Do the thing",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -449,7 +939,7 @@ func Test_createRun(t *testing.T) {
 				Sleep:            func(d time.Duration) {},
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
-				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string) (*capi.Job, error) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
 					require.Equal(t, "OWNER", owner)
 					require.Equal(t, "REPO", repo)
 					require.Equal(t, "Do the thing", problemStatement)
@@ -464,24 +954,28 @@ func Test_createRun(t *testing.T) {
 				}
 
 				var count int
-				m.GetSessionLogsFunc = func(_ context.Context, id string) ([]byte, error) {
+				m.GetSessionLogsFromFunc = func(_ context.Context, id string, offset int64) ([]byte, bool, error) {
 					assert.Equal(t, "sess1", id)
 
 					count++
 					require.Less(t, count, 3, "too many calls to fetch logs")
 					if count == 1 {
-						return []byte("<raw-logs-one>"), nil
+						assert.EqualValues(t, 0, offset)
+						return []byte("<raw-logs-one>"), true, nil
 					}
-					return []byte("<raw-logs-two>"), nil
+					assert.EqualValues(t, len("<raw-logs-one>"), offset)
+					return []byte("<raw-logs-two>"), true, nil
 				}
 			},
 			logRendererStubs: func(t *testing.T, m *shared.LogRendererMock) {
-				m.FollowFunc = func(fetcher func() ([]byte, error), w io.Writer, ios *iostreams.IOStreams) error {
-					raw, err := fetcher()
+				m.FollowFunc = func(fetcher shared.LogFetcher, w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) error {
+					var offset int64
+					raw, _, err := fetcher(offset)
 					require.NoError(t, err)
+					offset += int64(len(raw))
 					w.Write([]byte("(rendered:) " + string(raw) + "\n"))
 
-					raw, err = fetcher()
+					raw, _, err = fetcher(offset)
 					require.NoError(t, err)
 					w.Write([]byte("(rendered:) " + string(raw) + "\n"))
 					return nil
@@ -492,6 +986,74 @@ func Test_createRun(t *testing.T) {
 				(rendered:) <raw-logs-two>
 			`),
 		},
+		{
+			name: "follow-up on existing pr prints session url",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				ProblemStatement: "address the review comments",
+				PullRequest:      42,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetPullRequestDatabaseIDFunc = func(ctx context.Context, hostname, owner, repo string, number int) (int64, string, error) {
+					require.Equal(t, "github.com", hostname)
+					require.Equal(t, "OWNER", owner)
+					require.Equal(t, "REPO", repo)
+					require.Equal(t, 42, number)
+					return 999999, "https://github.com/OWNER/REPO/pull/42", nil
+				}
+				m.ListSessionsByResourceIDFunc = func(ctx context.Context, resourceType string, resourceID int64, limit int) ([]*capi.Session, error) {
+					require.Equal(t, "pull", resourceType)
+					require.Equal(t, int64(999999), resourceID)
+					require.Equal(t, 1, limit)
+					return []*capi.Session{{ID: "sess1"}}, nil
+				}
+				m.FollowUpJobFunc = func(ctx context.Context, owner, repo, sessionID, problemStatement string) (*capi.Job, error) {
+					require.Equal(t, "OWNER", owner)
+					require.Equal(t, "REPO", repo)
+					require.Equal(t, "sess1", sessionID)
+					require.Equal(t, "address the review comments", problemStatement)
+					return &capi.Job{ID: "job456", SessionID: "sess1"}, nil
+				}
+			},
+			wantStdout: "https://github.com/OWNER/REPO/pull/42/agent-sessions/sess1\n",
+		},
+		{
+			name: "follow-up with no sessions returns error",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				ProblemStatement: "address the review comments",
+				PullRequest:      42,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetPullRequestDatabaseIDFunc = func(ctx context.Context, hostname, owner, repo string, number int) (int64, string, error) {
+					return 999999, "https://github.com/OWNER/REPO/pull/42", nil
+				}
+				m.ListSessionsByResourceIDFunc = func(ctx context.Context, resourceType string, resourceID int64, limit int) ([]*capi.Session, error) {
+					return nil, nil
+				}
+			},
+			wantErr: "no agent session found for pull request #42",
+		},
+		{
+			name: "follow-up pr lookup failure surfaces error",
+			opts: &CreateOptions{
+				BaseRepo: func() (ghrepo.Interface, error) {
+					return ghrepo.New("OWNER", "REPO"), nil
+				},
+				ProblemStatement: "address the review comments",
+				PullRequest:      42,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetPullRequestDatabaseIDFunc = func(ctx context.Context, hostname, owner, repo string, number int) (int64, string, error) {
+					return 0, "", errors.New("some error")
+				}
+			},
+			wantErr: "failed to fetch pull request: some error",
+		},
 	}
 
 	for _, tt := range tests {
@@ -501,12 +1063,15 @@ func Test_createRun(t *testing.T) {
 				tt.capiStubs(t, capiClientMock)
 			}
 
-			ios, _, stdout, stderr := iostreams.Test()
+			ios, stdin, stdout, stderr := iostreams.Test()
 			if tt.isTTY {
 				ios.SetStdinTTY(true)
 				ios.SetStderrTTY(true)
 				ios.SetStdoutTTY(true)
 			}
+			if tt.stdin != "" {
+				stdin.WriteString(tt.stdin)
+			}
 
 			tt.opts.IO = ios
 			tt.opts.CapiClient = func() (capi.CapiClient, error) {
@@ -540,3 +1105,159 @@ func Test_createRun(t *testing.T) {
 		})
 	}
 }
+
+func Test_createRun_json(t *testing.T) {
+	jobWithoutPR := capi.Job{
+		ID:        "job123",
+		SessionID: "sess1",
+	}
+	jobWithPR := capi.Job{
+		ID:        "job123",
+		SessionID: "sess1",
+		PullRequest: &capi.JobPullRequest{
+			ID:     101,
+			Number: 42,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		args      string
+		capiStubs func(*testing.T, *capi.CapiClientMock)
+		wantOut   string
+	}{
+		{
+			name: "immediate PR",
+			args: "'Do the thing' --json jobId,sessionId,pullRequest,repo",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &jobWithPR, nil
+				}
+			},
+			wantOut: "{\"jobId\":\"job123\",\"pullRequest\":{\"number\":42,\"url\":\"https://github.com/OWNER/REPO/pull/42\"},\"repo\":\"OWNER/REPO\",\"sessionId\":\"sess1\"}\n",
+		},
+		{
+			name: "delayed PR after polling",
+			args: "'Do the thing' --json jobId,sessionId,pullRequest,repo",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &jobWithoutPR, nil
+				}
+				m.GetJobFunc = func(ctx context.Context, owner, repo, jobID string) (*capi.Job, error) {
+					return &jobWithPR, nil
+				}
+			},
+			wantOut: "{\"jobId\":\"job123\",\"pullRequest\":{\"number\":42,\"url\":\"https://github.com/OWNER/REPO/pull/42\"},\"repo\":\"OWNER/REPO\",\"sessionId\":\"sess1\"}\n",
+		},
+		{
+			name: "polling timeout leaves pullRequest null",
+			args: "'Do the thing' --json jobId,sessionId,pullRequest,repo",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &jobWithoutPR, nil
+				}
+				m.GetJobFunc = func(ctx context.Context, owner, repo, jobID string) (*capi.Job, error) {
+					return &jobWithoutPR, nil
+				}
+			},
+			wantOut: "{\"jobId\":\"job123\",\"pullRequest\":null,\"repo\":\"OWNER/REPO\",\"sessionId\":\"sess1\"}\n",
+		},
+		{
+			name: "jq filter extracts the session ID",
+			args: "'Do the thing' --json sessionId --jq .sessionId",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.CreateJobFunc = func(ctx context.Context, owner, repo, problemStatement, baseBranch string, draft *bool, contextRepos []string, agentID int64) (*capi.Job, error) {
+					return &jobWithPR, nil
+				}
+			},
+			wantOut: "sess1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capiClientMock := &capi.CapiClientMock{}
+			if tt.capiStubs != nil {
+				tt.capiStubs(t, capiClientMock)
+			}
+
+			ios, _, stdout, stderr := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: ios,
+			}
+
+			cmd := NewCmdCreate(f, func(opts *CreateOptions) error {
+				opts.BaseRepo = func() (ghrepo.Interface, error) { return ghrepo.New("OWNER", "REPO"), nil }
+				opts.CapiClient = func() (capi.CapiClient, error) { return capiClientMock, nil }
+				opts.BackOff = backoff.WithMaxRetries(&backoff.ZeroBackOff{}, 3)
+				return createRun(opts)
+			})
+
+			argv, err := shlex.Split(tt.args)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, stdout.String())
+			assert.Empty(t, stderr.String())
+		})
+	}
+}
+
+func Test_createTaskProgressLabel(t *testing.T) {
+	repo := ghrepo.New("OWNER", "REPO")
+
+	t.Run("default branch resolved for display", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+		reg.Register(
+			httpmock.GraphQL(`query RepositoryInfo\b`),
+			httpmock.StringResponse(`{"data":{"repository":{"defaultBranchRef":{"name":"trunk"}}}}`),
+		)
+
+		opts := &CreateOptions{
+			HttpClient: func() (*http.Client, error) {
+				return &http.Client{Transport: reg}, nil
+			},
+		}
+
+		assert.Equal(t, "Creating agent task in OWNER/REPO (base: trunk)...", createTaskProgressLabel(opts, repo))
+	})
+
+	t.Run("explicit base skips the lookup", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+
+		opts := &CreateOptions{
+			BaseBranch: "feature",
+			HttpClient: func() (*http.Client, error) {
+				return &http.Client{Transport: reg}, nil
+			},
+		}
+
+		assert.Equal(t, "Creating agent task in OWNER/REPO (base: feature)...", createTaskProgressLabel(opts, repo))
+		assert.Empty(t, reg.Requests)
+	})
+
+	t.Run("failed lookup drops the branch from the label", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		defer reg.Verify(t)
+		reg.Register(
+			httpmock.GraphQL(`query RepositoryInfo\b`),
+			httpmock.StatusStringResponse(500, "boom"),
+		)
+
+		opts := &CreateOptions{
+			HttpClient: func() (*http.Client, error) {
+				return &http.Client{Transport: reg}, nil
+			},
+		}
+
+		assert.Equal(t, "Creating agent task in OWNER/REPO...", createTaskProgressLabel(opts, repo))
+	})
+}
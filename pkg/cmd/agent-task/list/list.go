@@ -2,10 +2,17 @@ package list
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cli/cli/v2/internal/browser"
+	"github.com/cli/cli/v2/internal/ghinstance"
+	"github.com/cli/cli/v2/internal/ghrepo"
 	"github.com/cli/cli/v2/internal/tableprinter"
 	"github.com/cli/cli/v2/internal/text"
 	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
@@ -13,18 +20,48 @@ import (
 	prShared "github.com/cli/cli/v2/pkg/cmd/pr/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/cli/cli/v2/pkg/search"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 const defaultLimit = 30
 
+// allWarnThreshold is the session count past which --all prints a note, since
+// runs that large are slow and usually better served by filters.
+const allWarnThreshold = 1000
+
+// Named values accepted by --created-format; any other value is treated as a
+// Go time layout.
+const (
+	createdFormatRelative = "relative"
+	createdFormatRFC3339  = "rfc3339"
+)
+
+// Values accepted by --sort. Sorting by updated also adds an "Updated" column,
+// which is handy for spotting stalled tasks.
+const (
+	sortCreated = "created"
+	sortUpdated = "updated"
+)
+
 // ListOptions are the options for the list command
 type ListOptions struct {
-	IO         *iostreams.IOStreams
-	Limit      int
-	CapiClient func() (capi.CapiClient, error)
-	Web        bool
-	Browser    browser.Browser
+	IO            *iostreams.IOStreams
+	Limit         int
+	All           bool
+	Created       string
+	CreatedFormat string
+	Sort          string
+	Repos         []string
+	User          string
+	NoHydrate     bool
+	CapiClient    func() (capi.CapiClient, error)
+	Web           bool
+	Browser       browser.Browser
+	Exporter      cmdutil.Exporter
+	JSONL         bool
+	NoIcons       bool
 }
 
 // NewCmdList creates the list command
@@ -44,6 +81,35 @@ func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Comman
 			if opts.Limit < 1 {
 				return cmdutil.FlagErrorf("invalid limit: %v", opts.Limit)
 			}
+			if opts.All && cmd.Flags().Changed("limit") {
+				return cmdutil.FlagErrorf("--all cannot be used with --limit")
+			}
+			for _, repoName := range opts.Repos {
+				if _, err := ghrepo.FromFullName(repoName); err != nil {
+					return cmdutil.FlagErrorf("invalid value for --repo: %v", err)
+				}
+			}
+			if err := validateCreatedFormat(opts.CreatedFormat); err != nil {
+				return err
+			}
+			if err := cmdutil.MutuallyExclusive(
+				"specify only one of `--json` or `--jsonl`",
+				opts.Exporter != nil,
+				opts.JSONL,
+			); err != nil {
+				return err
+			}
+			// The login filter matches against hydrated user data.
+			if opts.User != "" && opts.NoHydrate {
+				return cmdutil.FlagErrorf("--user cannot be used with --no-hydrate")
+			}
+			if opts.NoHydrate {
+				opts.CapiClient = shared.CapiClientFunc(f, capi.WithoutHydration())
+			} else {
+				// The list summary never shows PR bodies or timestamps, so a
+				// minimal projection keeps hydration cheap at large limits.
+				opts.CapiClient = shared.CapiClientFunc(f, capi.WithMinimalPRFields())
+			}
 			if runF != nil {
 				return runF(opts)
 			}
@@ -52,24 +118,69 @@ func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Comman
 	}
 
 	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", defaultLimit, fmt.Sprintf("Maximum number of agent tasks to fetch (default %d)", defaultLimit))
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Fetch every agent task, paginating until the API runs out")
+	cmd.Flags().StringVar(&opts.Created, "created", "", `Filter by creation date using search syntax (e.g. ">2024-01-01", "2024-01-01..2024-02-01")`)
+	cmdutil.StringEnumFlag(cmd, &opts.Sort, "sort", "", "", []string{sortCreated, sortUpdated}, "Sort agent tasks by the given field, newest first")
+	cmd.Flags().StringVar(&opts.CreatedFormat, "created-format", "", "Format for the created column: {relative|rfc3339} or a Go time layout")
+	cmd.Flags().StringArrayVarP(&opts.Repos, "repo", "R", nil, "Filter by repository in `OWNER/REPO` format (can be repeated)")
+	cmd.Flags().StringVar(&opts.User, "user", "", "Filter by the `login` of the user the task was started on behalf of")
+	cmd.Flags().BoolVar(&opts.NoHydrate, "no-hydrate", false, "Skip fetching pull request and user details for each session")
 	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open agent tasks in the browser")
+	cmd.Flags().BoolVar(&opts.JSONL, "jsonl", false, "Output newline-delimited JSON, writing one object per session")
+	cmd.Flags().BoolVar(&opts.NoIcons, "no-icons", false, "Use plain ASCII characters instead of Unicode icons for session states")
+	cmdutil.AddJSONFlags(cmd, &opts.Exporter, capi.SessionFields)
 
 	return cmd
 }
 
 func listRun(opts *ListOptions) error {
 	if opts.Web {
-		webURL := capi.AgentsHomeURL
+		webURL := capi.AgentsHomeURL()
+		// A repo filter deep-links to that repository's sessions. The agents
+		// web UI only exists on github.com, so other hosts are rejected
+		// rather than silently opening the unfiltered global page.
+		if len(opts.Repos) > 0 {
+			u, err := url.Parse(webURL)
+			if err != nil {
+				return err
+			}
+			q := u.Query()
+			for _, repoName := range opts.Repos {
+				repo, err := ghrepo.FromFullName(repoName)
+				if err != nil {
+					return err
+				}
+				if repo.RepoHost() != ghinstance.Default() {
+					return fmt.Errorf("--web only supports filtering by repositories on %s", ghinstance.Default())
+				}
+				q.Add("repo", ghrepo.FullName(repo))
+			}
+			u.RawQuery = q.Encode()
+			webURL = u.String()
+		}
 		if opts.IO.IsStdoutTTY() {
 			fmt.Fprintf(opts.IO.ErrOut, "Opening %s in your browser.\n", text.DisplayURL(webURL))
 		}
 		return opts.Browser.Browse(webURL)
 	}
 
-	if opts.Limit <= 0 {
+	if opts.All {
+		// The pagination loop stops on its own when the API runs out of
+		// sessions, so an effectively infinite limit fetches everything.
+		opts.Limit = math.MaxInt
+	} else if opts.Limit <= 0 {
 		opts.Limit = defaultLimit
 	}
 
+	var createdFilter func(time.Time) bool
+	if opts.Created != "" {
+		var err error
+		createdFilter, err = search.ParseDateRange(opts.Created)
+		if err != nil {
+			return cmdutil.FlagErrorf("invalid value for --created: %v", err)
+		}
+	}
+
 	capiClient, err := opts.CapiClient()
 	if err != nil {
 		return err
@@ -80,14 +191,55 @@ func listRun(opts *ListOptions) error {
 	var sessions []*capi.Session
 	ctx := context.Background()
 
-	sessions, err = capiClient.ListLatestSessionsForViewer(ctx, opts.Limit)
+	if len(opts.Repos) > 0 {
+		sessions, err = listRepoSessions(ctx, capiClient, opts.Repos, opts.Limit)
+	} else {
+		sessions, err = capiClient.ListLatestSessionsForViewer(ctx, opts.Limit)
+	}
 	if err != nil {
 		return err
 	}
 
 	opts.IO.StopProgressIndicator()
 
-	if len(sessions) == 0 {
+	if opts.All && len(sessions) > allWarnThreshold {
+		fmt.Fprintf(opts.IO.ErrOut, "warning: fetched %d sessions; consider --limit or filters such as --repo for faster runs\n", len(sessions))
+	}
+
+	if createdFilter != nil {
+		filtered := make([]*capi.Session, 0, len(sessions))
+		for _, s := range sessions {
+			if createdFilter(s.CreatedAt) {
+				filtered = append(filtered, s)
+			}
+		}
+		sessions = filtered
+	}
+
+	// The sessions API has no server-side login filter, so filter the
+	// hydrated sessions client-side.
+	if opts.User != "" {
+		filtered := make([]*capi.Session, 0, len(sessions))
+		for _, s := range sessions {
+			if s.User != nil && strings.EqualFold(s.User.Login, opts.User) {
+				filtered = append(filtered, s)
+			}
+		}
+		sessions = filtered
+	}
+
+	switch opts.Sort {
+	case sortCreated:
+		slices.SortFunc(sessions, func(a, b *capi.Session) int {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		})
+	case sortUpdated:
+		slices.SortFunc(sessions, func(a, b *capi.Session) int {
+			return b.LastUpdatedAt.Compare(a.LastUpdatedAt)
+		})
+	}
+
+	if len(sessions) == 0 && opts.Exporter == nil && !opts.JSONL {
 		return cmdutil.NewNoResultsError("no agent tasks found")
 	}
 
@@ -97,27 +249,94 @@ func listRun(opts *ListOptions) error {
 		fmt.Fprintf(opts.IO.ErrOut, "error starting pager: %v\n", err)
 	}
 
+	// JSON-lines output writes each session as soon as it is serialized
+	// instead of buffering the whole result set into one document, so
+	// downstream tools can start processing before the last session is out.
+	if opts.JSONL {
+		enc := json.NewEncoder(opts.IO.Out)
+		for _, s := range sessions {
+			if err := enc.Encode(s.ExportData(capi.SessionFields)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if opts.Exporter != nil {
+		return opts.Exporter.Write(opts.IO, sessions)
+	}
+
 	if opts.IO.IsStdoutTTY() {
 		count := len(sessions)
 		header := fmt.Sprintf("Showing %s", text.Pluralize(count, "session"))
 		fmt.Fprintf(opts.IO.Out, "%s\n\n", header)
 	}
 
+	// Resolve agent IDs into names. Best effort, and skipped entirely when no
+	// session carries an agent ID. The agent column is only rendered when at
+	// least one name resolves, so the table doesn't widen for nothing.
+	agentNames := map[int64]string{}
+	if slices.ContainsFunc(sessions, func(s *capi.Session) bool { return s.AgentID != 0 }) {
+		if names, err := capi.AgentNamesByID(ctx, capiClient); err == nil {
+			agentNames = names
+		}
+	}
+	showAgents := len(agentNames) > 0
+
 	cs := opts.IO.ColorScheme()
-	tp := tableprinter.New(opts.IO, tableprinter.WithHeader("Session Name", "Pull Request", "Repo", "Session State", "Created"))
+	sessionSymbol := shared.SessionSymbol
+	if opts.NoIcons {
+		sessionSymbol = shared.SessionSymbolASCII
+	}
+	showUpdated := opts.Sort == sortUpdated
+	headers := []string{"Session Name", "Pull Request", "Repo", "Session State", "Created"}
+	if showUpdated {
+		headers = append(headers, "Updated")
+	}
+	if showAgents {
+		headers = slices.Insert(headers, 1, "Agent")
+	}
+	tp := tableprinter.New(opts.IO, tableprinter.WithHeader(headers...))
 	for _, s := range sessions {
-		if s.ResourceType != "pull" || s.PullRequest == nil || s.PullRequest.Repository == nil {
-			// Skip these sessions in case they happen, for now.
+		if s.ResourceType != "pull" && s.ResourceType != "issue" {
 			continue
 		}
 
-		pr := fmt.Sprintf("#%d", s.PullRequest.Number)
-		repo := s.PullRequest.Repository.NameWithOwner
+		pr := "-"
+		repo := "-"
+		var prColor string
+		if s.PullRequest != nil && s.PullRequest.Repository != nil {
+			pr = fmt.Sprintf("#%d", s.PullRequest.Number)
+			repo = s.PullRequest.Repository.NameWithOwner
+			prColor = prShared.ColorForPRState(*s.PullRequest)
+		} else if s.Issue != nil {
+			pr = fmt.Sprintf("#%d", s.Issue.Number)
+			// The issue hydration projection carries no repository, so derive
+			// it from the issue URL's /OWNER/REPO/issues/N path.
+			if u, err := url.Parse(s.Issue.URL); err == nil {
+				if parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 3); len(parts) >= 2 {
+					repo = parts[0] + "/" + parts[1]
+				}
+			}
+		} else if !opts.NoHydrate {
+			// Skip these sessions in case they happen, for now.
+			continue
+		}
 
 		// Name
 		tp.AddField(s.Name)
+
+		// Agent
+		if showAgents {
+			agent := "-"
+			if name := agentNames[s.AgentID]; name != "" {
+				agent = name
+			}
+			tp.AddField(agent, tableprinter.WithColor(cs.Muted))
+		}
+
 		if tp.IsTTY() {
-			tp.AddField(pr, tableprinter.WithColor(cs.ColorFromString(prShared.ColorForPRState(*s.PullRequest))))
+			tp.AddField(pr, tableprinter.WithColor(cs.ColorFromString(prColor)))
 		} else {
 			tp.AddField(pr)
 		}
@@ -127,16 +346,38 @@ func listRun(opts *ListOptions) error {
 
 		// State
 		if tp.IsTTY() {
-			tp.AddField(shared.SessionStateString(s.State), tableprinter.WithColor(shared.ColorFuncForSessionState(*s, cs)))
+			stateColor := shared.ColorFuncForSessionState(*s, cs)
+			tp.AddField(fmt.Sprintf("%s %s", sessionSymbol(cs, s.State), stateColor(shared.SessionStateString(s.State))))
 		} else {
 			tp.AddField(shared.SessionStateString(s.State))
 		}
 
 		// Created
-		if tp.IsTTY() {
-			tp.AddTimeField(time.Now(), s.CreatedAt, cs.Muted)
+		if opts.CreatedFormat == createdFormatRelative || (opts.CreatedFormat == "" && tp.IsTTY()) {
+			if tp.IsTTY() {
+				tp.AddTimeField(time.Now(), s.CreatedAt, cs.Muted)
+			} else {
+				tp.AddField(text.FuzzyAgo(time.Now(), s.CreatedAt))
+			}
 		} else {
-			tp.AddField(s.CreatedAt.Format(time.RFC3339))
+			layout := time.RFC3339
+			if opts.CreatedFormat != "" && opts.CreatedFormat != createdFormatRFC3339 {
+				layout = opts.CreatedFormat
+			}
+			if tp.IsTTY() {
+				tp.AddField(s.CreatedAt.Format(layout), tableprinter.WithColor(cs.Muted))
+			} else {
+				tp.AddField(s.CreatedAt.Format(layout))
+			}
+		}
+
+		// Updated
+		if showUpdated {
+			if tp.IsTTY() {
+				tp.AddTimeField(time.Now(), s.LastUpdatedAt, cs.Muted)
+			} else {
+				tp.AddField(s.LastUpdatedAt.Format(time.RFC3339))
+			}
 		}
 
 		tp.EndRow()
@@ -148,3 +389,74 @@ func listRun(opts *ListOptions) error {
 
 	return nil
 }
+
+// validateCreatedFormat checks that the given --created-format value is one
+// of the named formats or a usable Go time layout. A layout is considered
+// usable when it contains at least one time element and survives a
+// format/parse round trip.
+func validateCreatedFormat(format string) error {
+	switch format {
+	case "", createdFormatRelative, createdFormatRFC3339:
+		return nil
+	}
+
+	// The reference time deliberately shares no component with Go's layout
+	// reference time, so a layout without any time elements renders
+	// unchanged and is rejected.
+	ref := time.Date(2024, 11, 22, 10, 30, 45, 0, time.UTC)
+	rendered := ref.Format(format)
+	if rendered == format {
+		return cmdutil.FlagErrorf("invalid value for --created-format: %q is not %q, %q, or a Go time layout", format, createdFormatRelative, createdFormatRFC3339)
+	}
+	if _, err := time.Parse(format, rendered); err != nil {
+		return cmdutil.FlagErrorf("invalid value for --created-format: %q is not a valid Go time layout", format)
+	}
+	return nil
+}
+
+// listRepoSessions fetches sessions for each repository concurrently, then
+// merges them into a single newest-first list capped at limit. Sessions that
+// appear under more than one repository scope are deduplicated by ID.
+func listRepoSessions(ctx context.Context, capiClient capi.CapiClient, repos []string, limit int) ([]*capi.Session, error) {
+	perRepo := make([][]*capi.Session, len(repos))
+	var g errgroup.Group
+	g.SetLimit(4)
+	for i, repoName := range repos {
+		g.Go(func() error {
+			repo, err := ghrepo.FromFullName(repoName)
+			if err != nil {
+				return err
+			}
+			sessions, err := capiClient.ListSessionsForRepo(ctx, repo.RepoOwner(), repo.RepoName(), limit)
+			if err != nil {
+				return err
+			}
+			perRepo[i] = sessions
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var merged []*capi.Session
+	for _, sessions := range perRepo {
+		for _, s := range sessions {
+			if _, ok := seen[s.ID]; ok {
+				continue
+			}
+			seen[s.ID] = struct{}{}
+			merged = append(merged, s)
+		}
+	}
+
+	slices.SortFunc(merged, func(a, b *capi.Session) int {
+		return b.LastUpdatedAt.Compare(a.LastUpdatedAt)
+	})
+
+	if len(merged) > limit {
+		merged = merged[:limit]
+	}
+	return merged, nil
+}
@@ -3,7 +3,11 @@ package list
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"io"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,6 +52,19 @@ func TestNewCmdList(t *testing.T) {
 			args:    "--limit -5",
 			wantErr: "invalid limit: -5",
 		},
+		{
+			name: "all flag",
+			args: "--all",
+			wantOpts: ListOptions{
+				Limit: defaultLimit,
+				All:   true,
+			},
+		},
+		{
+			name:    "all flag with limit",
+			args:    "--all --limit 15",
+			wantErr: "--all cannot be used with --limit",
+		},
 		{
 			name: "web flag",
 			args: "--web",
@@ -56,6 +73,95 @@ func TestNewCmdList(t *testing.T) {
 				Web:   true,
 			},
 		},
+		{
+			name: "no-hydrate flag",
+			args: "--no-hydrate",
+			wantOpts: ListOptions{
+				Limit:     defaultLimit,
+				NoHydrate: true,
+			},
+		},
+		{
+			name: "repeated repo flag",
+			args: "--repo OWNER/REPO -R OTHER/REPO2",
+			wantOpts: ListOptions{
+				Limit: defaultLimit,
+				Repos: []string{"OWNER/REPO", "OTHER/REPO2"},
+			},
+		},
+		{
+			name:    "invalid repo",
+			args:    "--repo not-a-repo",
+			wantErr: `invalid value for --repo: expected the "[HOST/]OWNER/REPO" format, got "not-a-repo"`,
+		},
+		{
+			name: "user flag",
+			args: "--user octocat",
+			wantOpts: ListOptions{
+				Limit: defaultLimit,
+				User:  "octocat",
+			},
+		},
+		{
+			name:    "user flag with no-hydrate",
+			args:    "--user octocat --no-hydrate",
+			wantErr: "--user cannot be used with --no-hydrate",
+		},
+		{
+			name: "sort flag",
+			args: "--sort updated",
+			wantOpts: ListOptions{
+				Limit: defaultLimit,
+				Sort:  "updated",
+			},
+		},
+		{
+			name:    "invalid sort",
+			args:    "--sort stalest",
+			wantErr: `invalid argument "stalest" for "--sort" flag: valid values are {created|updated}`,
+		},
+		{
+			name: "jsonl flag",
+			args: "--jsonl",
+			wantOpts: ListOptions{
+				Limit: defaultLimit,
+				JSONL: true,
+			},
+		},
+		{
+			name:    "jsonl with json",
+			args:    "--jsonl --json id",
+			wantErr: "specify only one of `--json` or `--jsonl`",
+		},
+		{
+			name: "no-icons flag",
+			args: "--no-icons",
+			wantOpts: ListOptions{
+				Limit:   defaultLimit,
+				NoIcons: true,
+			},
+		},
+		{
+			name: "named created format",
+			args: "--created-format rfc3339",
+			wantOpts: ListOptions{
+				Limit:         defaultLimit,
+				CreatedFormat: "rfc3339",
+			},
+		},
+		{
+			name: "layout created format",
+			args: "--created-format 2006-01-02",
+			wantOpts: ListOptions{
+				Limit:         defaultLimit,
+				CreatedFormat: "2006-01-02",
+			},
+		},
+		{
+			name:    "invalid created format",
+			args:    "--created-format bogus",
+			wantErr: `invalid value for --created-format: "bogus" is not "relative", "rfc3339", or a Go time layout`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,7 +190,15 @@ func TestNewCmdList(t *testing.T) {
 			}
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantOpts.Limit, gotOpts.Limit)
+			assert.Equal(t, tt.wantOpts.All, gotOpts.All)
 			assert.Equal(t, tt.wantOpts.Web, gotOpts.Web)
+			assert.Equal(t, tt.wantOpts.NoHydrate, gotOpts.NoHydrate)
+			assert.Equal(t, tt.wantOpts.Repos, gotOpts.Repos)
+			assert.Equal(t, tt.wantOpts.User, gotOpts.User)
+			assert.Equal(t, tt.wantOpts.Sort, gotOpts.Sort)
+			assert.Equal(t, tt.wantOpts.CreatedFormat, gotOpts.CreatedFormat)
+			assert.Equal(t, tt.wantOpts.JSONL, gotOpts.JSONL)
+			assert.Equal(t, tt.wantOpts.NoIcons, gotOpts.NoIcons)
 		})
 	}
 }
@@ -98,7 +212,14 @@ func Test_listRun(t *testing.T) {
 		tty            bool
 		capiStubs      func(*testing.T, *capi.CapiClientMock)
 		limit          int
+		all            bool
+		created        string
+		createdFormat  string
+		sort           string
+		repos          []string
+		user           string
 		web            bool
+		noIcons        bool
 		wantOut        string
 		wantErr        error
 		wantStderr     string
@@ -126,6 +247,18 @@ func Test_listRun(t *testing.T) {
 			},
 			wantErr: cmdutil.NewNoResultsError("no agent tasks found"), // not important
 		},
+		{
+			name: "viewer-scoped --all ignores the numeric limit",
+			tty:  true,
+			all:  true,
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					assert.Equal(t, math.MaxInt, limit)
+					return nil, nil
+				}
+			},
+			wantErr: cmdutil.NewNoResultsError("no agent tasks found"), // not important
+		},
 		{
 			name: "viewer-scoped single session (tty)",
 			tty:  true,
@@ -151,8 +284,38 @@ func Test_listRun(t *testing.T) {
 			wantOut: heredoc.Doc(`
 				Showing 1 session
 
-				SESSION NAME  PULL REQUEST  REPO        SESSION STATE     CREATED
-				s1            #101          OWNER/REPO  Ready for review  about 6 hours ago
+				SESSION NAME  PULL REQUEST  REPO        SESSION STATE       CREATED
+				s1            #101          OWNER/REPO  ✓ Ready for review  about 6 hours ago
+			`),
+		},
+		{
+			name:    "viewer-scoped single session with --no-icons (tty)",
+			tty:     true,
+			noIcons: true,
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Showing 1 session
+
+				SESSION NAME  PULL REQUEST  REPO        SESSION STATE       CREATED
+				s1            #101          OWNER/REPO  + Ready for review  about 6 hours ago
 			`),
 		},
 		{
@@ -179,6 +342,30 @@ func Test_listRun(t *testing.T) {
 			},
 			wantOut: "s1\t#101\tOWNER/REPO\tReady for review\t" + sampleDateString + "\n", // header omitted for non-tty
 		},
+		{
+			name: "viewer-scoped issue-backed session (nontty)",
+			tty:  false,
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							ResourceType: "issue",
+							CreatedAt:    sampleDate,
+							Issue: &api.Issue{
+								Number: 7,
+								Title:  "Flaky test",
+								State:  "OPEN",
+								URL:    "https://github.com/OWNER/REPO/issues/7",
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "s1\t#7\tOWNER/REPO\tReady for review\t" + sampleDateString + "\n",
+		},
 		{
 			name: "viewer-scoped many sessions (tty)",
 			tty:  true,
@@ -269,15 +456,373 @@ func Test_listRun(t *testing.T) {
 			wantOut: heredoc.Doc(`
 				Showing 6 sessions
 
-				SESSION NAME  PULL REQUEST  REPO        SESSION STATE     CREATED
-				s1            #101          OWNER/REPO  Ready for review  about 6 hours ago
-				s2            #102          OWNER/REPO  Failed            about 6 hours ago
-				s3            #103          OWNER/REPO  In progress       about 6 hours ago
-				s4            #104          OWNER/REPO  Queued            about 6 hours ago
-				s5            #105          OWNER/REPO  Cancelled         about 6 hours ago
-				s6            #106          OWNER/REPO  mystery           about 6 hours ago
+				SESSION NAME  PULL REQUEST  REPO        SESSION STATE       CREATED
+				s1            #101          OWNER/REPO  ✓ Ready for review  about 6 hours ago
+				s2            #102          OWNER/REPO  X Failed            about 6 hours ago
+				s3            #103          OWNER/REPO  ● In progress       about 6 hours ago
+				s4            #104          OWNER/REPO  ● Queued            about 6 hours ago
+				s5            #105          OWNER/REPO  X Cancelled         about 6 hours ago
+				s6            #106          OWNER/REPO  - mystery           about 6 hours ago
+			`),
+		},
+		{
+			name: "agent IDs resolved to names (tty)",
+			tty:  true,
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							AgentID:      2,
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+						{
+							ID:           "id2",
+							Name:         "s2",
+							State:        "completed",
+							AgentID:      99,
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 102,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+				m.ListAgentsFunc = func(ctx context.Context) ([]*capi.Agent, error) {
+					return []*capi.Agent{
+						{ID: 2, Name: "Copilot"},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Showing 2 sessions
+
+				SESSION NAME  AGENT    PULL REQUEST  REPO        SESSION STATE     CREATED
+				s1            Copilot  #101          OWNER/REPO  ✓ Ready for r...  about 6 ho...
+				s2            -        #102          OWNER/REPO  ✓ Ready for r...  about 6 ho...
+			`),
+		},
+		{
+			name: "sort updated orders by LastUpdatedAt and adds a column (nontty)",
+			tty:  false,
+			sort: "updated",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:            "id1",
+							Name:          "s1",
+							State:         "completed",
+							ResourceType:  "pull",
+							CreatedAt:     sampleDate,
+							LastUpdatedAt: sampleDate.Add(2 * time.Hour),
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+						{
+							ID:            "id2",
+							Name:          "s2",
+							State:         "in_progress",
+							ResourceType:  "pull",
+							CreatedAt:     sampleDate.Add(time.Hour),
+							LastUpdatedAt: sampleDate.Add(4 * time.Hour),
+							PullRequest: &api.PullRequest{
+								Number: 102,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "s2\t#102\tOWNER/REPO\tIn progress\t" + sampleDate.Add(time.Hour).Format(time.RFC3339) + "\t" + sampleDate.Add(4*time.Hour).Format(time.RFC3339) + "\n" +
+				"s1\t#101\tOWNER/REPO\tReady for review\t" + sampleDateString + "\t" + sampleDate.Add(2*time.Hour).Format(time.RFC3339) + "\n",
+		},
+		{
+			name:    "created filter keeps matching sessions",
+			tty:     false,
+			created: "2024-01-01..2024-02-01",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "in-range",
+							State:        "completed",
+							CreatedAt:    time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+						{
+							ID:           "id2",
+							Name:         "out-of-range",
+							State:        "completed",
+							CreatedAt:    time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 102,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "in-range\t#101\tOWNER/REPO\tReady for review\t2024-02-01T12:00:00Z\n",
+		},
+		{
+			name:    "created filter with no matches",
+			tty:     true,
+			created: ">2099-01-01",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantErr: cmdutil.NewNoResultsError("no agent tasks found"),
+		},
+		{
+			name:  "multiple repos merged, deduped, and sorted",
+			tty:   false,
+			repos: []string{"OWNER/REPO", "OTHER/REPO2"},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				repoSession := func(id, name, nameWithOwner string, number int, updated time.Time) *capi.Session {
+					return &capi.Session{
+						ID:            id,
+						Name:          name,
+						State:         "completed",
+						CreatedAt:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+						LastUpdatedAt: updated,
+						ResourceType:  "pull",
+						PullRequest: &api.PullRequest{
+							Number: number,
+							Repository: &api.PRRepository{
+								NameWithOwner: nameWithOwner,
+							},
+						},
+					}
+				}
+				m.ListSessionsForRepoFunc = func(ctx context.Context, owner, repo string, limit int) ([]*capi.Session, error) {
+					switch owner + "/" + repo {
+					case "OWNER/REPO":
+						return []*capi.Session{
+							repoSession("id1", "oldest", "OWNER/REPO", 101, time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)),
+							repoSession("id2", "dup", "OWNER/REPO", 102, time.Date(2024, 2, 1, 2, 0, 0, 0, time.UTC)),
+						}, nil
+					case "OTHER/REPO2":
+						return []*capi.Session{
+							repoSession("id2", "dup", "OWNER/REPO", 102, time.Date(2024, 2, 1, 2, 0, 0, 0, time.UTC)),
+							repoSession("id3", "newest", "OTHER/REPO2", 201, time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)),
+						}, nil
+					default:
+						require.FailNow(t, "unexpected repo", "%s/%s", owner, repo)
+						return nil, nil
+					}
+				}
+			},
+			wantOut: heredoc.Doc(`
+				newest	#201	OTHER/REPO2	Ready for review	2024-02-01T00:00:00Z
+				dup	#102	OWNER/REPO	Ready for review	2024-02-01T00:00:00Z
+				oldest	#101	OWNER/REPO	Ready for review	2024-02-01T00:00:00Z
+			`),
+		},
+		{
+			name:  "multiple repos respect combined limit",
+			tty:   false,
+			limit: 1,
+			repos: []string{"OWNER/REPO", "OTHER/REPO2"},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListSessionsForRepoFunc = func(ctx context.Context, owner, repo string, limit int) ([]*capi.Session, error) {
+					assert.Equal(t, 1, limit)
+					updated := time.Date(2024, 2, 1, 1, 0, 0, 0, time.UTC)
+					if owner == "OWNER" {
+						updated = updated.Add(time.Hour)
+					}
+					return []*capi.Session{
+						{
+							ID:            owner + "-id",
+							Name:          owner,
+							State:         "completed",
+							CreatedAt:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+							LastUpdatedAt: updated,
+							ResourceType:  "pull",
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: owner + "/" + repo,
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "OWNER\t#101\tOWNER/REPO\tReady for review\t2024-02-01T00:00:00Z\n",
+		},
+		{
+			name:          "relative created format (nontty)",
+			tty:           false,
+			createdFormat: "relative",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							ResourceType: "pull",
+							CreatedAt:    sampleDate,
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "s1\t#101\tOWNER/REPO\tReady for review\tabout 6 hours ago\n",
+		},
+		{
+			name:          "layout created format (nontty)",
+			tty:           false,
+			createdFormat: "2006-01-02",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							ResourceType: "pull",
+							CreatedAt:    sampleDate,
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: "s1\t#101\tOWNER/REPO\tReady for review\t" + sampleDate.Format("2006-01-02") + "\n",
+		},
+		{
+			name:    "invalid created filter",
+			tty:     true,
+			created: "not-a-date",
+			wantErr: cmdutil.FlagErrorf(`invalid value for --created: invalid date "not-a-date" (expected YYYY-MM-DD or RFC3339)`),
+		},
+		{
+			name: "user filter keeps matching sessions (case-insensitive)",
+			tty:  true,
+			user: "octocat",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							User:         &api.GitHubUser{Login: "Octocat"},
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+						{
+							ID:           "id2",
+							Name:         "s2",
+							State:        "completed",
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							User:         &api.GitHubUser{Login: "hubot"},
+							PullRequest: &api.PullRequest{
+								Number: 102,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Showing 1 session
+
+				SESSION NAME  PULL REQUEST  REPO        SESSION STATE       CREATED
+				s1            #101          OWNER/REPO  ✓ Ready for review  about 6 hours ago
 			`),
 		},
+		{
+			name: "user filter with no matching login",
+			tty:  true,
+			user: "someone-else",
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{
+							ID:           "id1",
+							Name:         "s1",
+							State:        "completed",
+							CreatedAt:    sampleDate,
+							ResourceType: "pull",
+							User:         &api.GitHubUser{Login: "octocat"},
+							PullRequest: &api.PullRequest{
+								Number: 101,
+								Repository: &api.PRRepository{
+									NameWithOwner: "OWNER/REPO",
+								},
+							},
+						},
+					}, nil
+				}
+			},
+			wantErr: cmdutil.NewNoResultsError("no agent tasks found"),
+		},
 		{
 			name:           "web mode",
 			tty:            true,
@@ -286,6 +831,22 @@ func Test_listRun(t *testing.T) {
 			wantStderr:     "Opening https://github.com/copilot/agents in your browser.\n",
 			wantBrowserURL: "https://github.com/copilot/agents",
 		},
+		{
+			name:           "web mode with repo filter",
+			tty:            true,
+			web:            true,
+			repos:          []string{"OWNER/REPO"},
+			wantOut:        "",
+			wantStderr:     "Opening https://github.com/copilot/agents in your browser.\n",
+			wantBrowserURL: "https://github.com/copilot/agents?repo=OWNER%2FREPO",
+		},
+		{
+			name:    "web mode with repo filter on another host",
+			tty:     true,
+			web:     true,
+			repos:   []string{"example.com/OWNER/REPO"},
+			wantErr: errors.New("--web only supports filtering by repositories on github.com"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -304,10 +865,17 @@ func Test_listRun(t *testing.T) {
 			}
 
 			opts := &ListOptions{
-				IO:      ios,
-				Limit:   tt.limit,
-				Web:     tt.web,
-				Browser: br,
+				IO:            ios,
+				Limit:         tt.limit,
+				All:           tt.all,
+				Created:       tt.created,
+				CreatedFormat: tt.createdFormat,
+				Sort:          tt.sort,
+				Repos:         tt.repos,
+				User:          tt.user,
+				Web:           tt.web,
+				NoIcons:       tt.noIcons,
+				Browser:       br,
 				CapiClient: func() (capi.CapiClient, error) {
 					if tt.web {
 						require.FailNow(t, "CapiClient was called with --web")
@@ -332,3 +900,92 @@ func Test_listRun(t *testing.T) {
 		})
 	}
 }
+
+func Test_listRun_jsonl(t *testing.T) {
+	capiClientMock := &capi.CapiClientMock{
+		ListLatestSessionsForViewerFunc: func(ctx context.Context, limit int) ([]*capi.Session, error) {
+			return []*capi.Session{
+				{ID: "id1", Name: "s1", State: "completed"},
+				{ID: "id2", Name: "s2", State: "failed"},
+			}, nil
+		},
+	}
+
+	ios, _, stdout, stderr := iostreams.Test()
+	opts := &ListOptions{
+		IO:         ios,
+		JSONL:      true,
+		CapiClient: func() (capi.CapiClient, error) { return capiClientMock, nil },
+	}
+
+	require.NoError(t, listRun(opts))
+
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for i, line := range lines {
+		var obj map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &obj), "line %d is not a JSON object", i)
+	}
+	assert.Contains(t, lines[0], `"id":"id1"`)
+	assert.Contains(t, lines[1], `"id":"id2"`)
+	assert.Empty(t, stderr.String())
+}
+
+func Test_listRun_json(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		wantOut string
+	}{
+		{
+			name:    "json fields",
+			args:    "--json id,state",
+			wantOut: "[{\"id\":\"id1\",\"state\":\"completed\"},{\"id\":\"id2\",\"state\":\"failed\"}]\n",
+		},
+		{
+			name:    "jq filter",
+			args:    "--json state --jq '.[] | .state'",
+			wantOut: "completed\nfailed\n",
+		},
+		{
+			name:    "template",
+			args:    `--json name,state --template '{{range .}}{{.name}}: {{.state}}{{"\n"}}{{end}}'`,
+			wantOut: "s1: completed\ns2: failed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capiClientMock := &capi.CapiClientMock{
+				ListLatestSessionsForViewerFunc: func(ctx context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{ID: "id1", Name: "s1", State: "completed"},
+						{ID: "id2", Name: "s2", State: "failed"},
+					}, nil
+				},
+			}
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: ios,
+			}
+
+			cmd := NewCmdList(f, func(opts *ListOptions) error {
+				opts.CapiClient = func() (capi.CapiClient, error) { return capiClientMock, nil }
+				return listRun(opts)
+			})
+
+			argv, err := shlex.Split(tt.args)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, stdout.String())
+		})
+	}
+}
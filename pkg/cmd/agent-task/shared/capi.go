@@ -1,51 +1,114 @@
 package shared
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"regexp"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/cli/cli/v2/internal/ghinstance"
 	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
 	prShared "github.com/cli/cli/v2/pkg/cmd/pr/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/utils"
 )
 
 const uuidPattern = `[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}`
 
-var sessionIDRegexp = regexp.MustCompile(fmt.Sprintf("^%s$", uuidPattern))
-var agentSessionURLRegexp = regexp.MustCompile(fmt.Sprintf("^/agent-sessions/(%s)$", uuidPattern))
+// A trailing slash is tolerated since browsers and copy-pasted URLs often
+// carry one; queries and fragments never reach the regexp because the URL
+// parse upstream keeps only the path.
+var agentSessionURLRegexp = regexp.MustCompile(fmt.Sprintf("^/agent-sessions/(%s)/?$", uuidPattern))
 
-func CapiClientFunc(f *cmdutil.Factory) func() (capi.CapiClient, error) {
+// CapiClientFunc returns a lazy constructor for a CAPI client. The first call
+// verifies that the Copilot coding agent is available to the authenticated
+// user; the outcome (client or error) is cached for the rest of the command
+// invocation so the probe runs at most once.
+func CapiClientFunc(f *cmdutil.Factory, opts ...capi.CAPIClientOption) func() (capi.CapiClient, error) {
+	var once sync.Once
+	var client capi.CapiClient
+	var clientErr error
 	return func() (capi.CapiClient, error) {
-		cfg, err := f.Config()
-		if err != nil {
-			return nil, err
-		}
+		once.Do(func() {
+			cfg, err := f.Config()
+			if err != nil {
+				clientErr = err
+				return
+			}
 
-		httpClient, err := f.HttpClient()
-		if err != nil {
-			return nil, err
-		}
+			httpClient, err := f.HttpClient()
+			if err != nil {
+				clientErr = err
+				return
+			}
+
+			// Integrations embedding the CLI can attribute created jobs to
+			// themselves for telemetry by overriding the event type.
+			if eventType := os.Getenv("GH_AGENT_TASK_EVENT_TYPE"); eventType != "" {
+				if strings.ContainsAny(eventType, " \t") {
+					clientErr = fmt.Errorf("invalid GH_AGENT_TASK_EVENT_TYPE %q: must not contain whitespace", eventType)
+					return
+				}
+				opts = append(opts, capi.WithEventType(eventType))
+			}
 
-		authCfg := cfg.Authentication()
-		return capi.NewCAPIClient(httpClient, authCfg), nil
+			// When GH_DEBUG is enabled, trace each CAPI request to stderr the
+			// same way verbose API logging does for the main HTTP client.
+			if debug, _ := utils.IsDebugEnabled(); debug {
+				stderr := f.IOStreams.ErrOut
+				opts = append(opts, capi.WithTrace(func(method, url string, statusCode int, duration time.Duration) {
+					fmt.Fprintf(stderr, "* CAPI %s %s %d (%s)\n", method, url, statusCode, duration.Round(time.Millisecond))
+				}))
+			}
+
+			authCfg := cfg.Authentication()
+			c := capi.NewCAPIClient(httpClient, authCfg, opts...)
+			if err := c.VerifyAgentAccess(context.Background()); err != nil {
+				clientErr = err
+				return
+			}
+			client = c
+		})
+		return client, clientErr
 	}
 }
 
 func IsSessionID(s string) bool {
-	return sessionIDRegexp.MatchString(s)
+	return capi.IsSessionID(s)
 }
 
 // ParseSessionIDFromURL parses session ID from a pull request's agent session
 // URL, which is of the form:
 //
 //	`https://github.com/OWNER/REPO/pull/NUMBER/agent-sessions/SESSION-ID`
+//
+// A bare agent session path (`/agent-sessions/SESSION-ID`) is also accepted.
+// Full URLs must point at github.com: agent tasks are not available on other
+// hosts, so a session ID parsed from one would never resolve.
 func ParseSessionIDFromURL(u string) (string, error) {
-	_, _, rest, err := prShared.ParseURL(u)
+	if strings.HasPrefix(u, "/") {
+		if parsed, err := url.Parse(u); err == nil {
+			if match := agentSessionURLRegexp.FindStringSubmatch(parsed.Path); match != nil {
+				return match[1], nil
+			}
+		}
+		return "", errors.New("not a valid agent session URL")
+	}
+
+	repo, _, rest, err := prShared.ParseURL(u)
 	if err != nil {
 		return "", err
 	}
 
+	if repo.RepoHost() != ghinstance.Default() {
+		return "", fmt.Errorf("agent tasks are not supported on this host: %s", repo.RepoHost())
+	}
+
 	match := agentSessionURLRegexp.FindStringSubmatch(rest)
 	if match == nil {
 		return "", errors.New("not a valid agent session URL")
@@ -1,8 +1,17 @@
 package shared
 
 import (
+	"context"
+	"net/http"
+	"net/url"
 	"testing"
 
+	"github.com/cli/cli/v2/internal/config"
+	"github.com/cli/cli/v2/internal/gh"
+	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/httpmock"
+	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,6 +41,61 @@ func TestParsePullRequestAgentSessionURL(t *testing.T) {
 			url:           "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
 		},
+		{
+			name:          "valid with trailing slash",
+			url:           "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf/",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:          "valid with query string",
+			url:           "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf?tab=logs",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:          "valid with fragment",
+			url:           "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf#logs",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:          "valid with query string and fragment",
+			url:           "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf/?tab=logs#anchor",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:    "extra path component after session id",
+			url:     "https://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf/extra",
+			wantErr: true,
+		},
+		{
+			name:          "bare agent session path",
+			url:           "/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:          "bare agent session path with query string",
+			url:           "/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf?tab=logs",
+			wantSessionID: "e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+		},
+		{
+			name:    "bare path without session id",
+			url:     "/agent-sessions/",
+			wantErr: true,
+		},
+		{
+			name:    "bare path with invalid session id",
+			url:     "/agent-sessions/fff",
+			wantErr: true,
+		},
+		{
+			name:    "non-github host",
+			url:     "https://example.ghe.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			url:     "ssh://github.com/OWNER/REPO/pull/123/agent-sessions/e2fa49d2-f164-4a56-ab99-498090b8fcdf",
+			wantErr: true,
+		},
 		{
 			name:    "invalid session id",
 			url:     "https://github.com/OWNER/REPO/pull/123/agent-sessions/fff",
@@ -74,3 +138,145 @@ func TestParsePullRequestAgentSessionURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCapiClientFuncProbesAccessOnce(t *testing.T) {
+	probeStub := func(reg *httpmock.Registry, status int) {
+		reg.Register(
+			httpmock.WithHost(
+				httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+					"page_number": {"1"},
+					"page_size":   {"1"},
+				}),
+				"api.githubcopilot.com",
+			),
+			httpmock.StatusStringResponse(status, `{}`),
+		)
+	}
+
+	newFactory := func(reg *httpmock.Registry) *cmdutil.Factory {
+		return &cmdutil.Factory{
+			Config: func() (gh.Config, error) {
+				return config.NewBlankConfig(), nil
+			},
+			HttpClient: func() (*http.Client, error) {
+				return &http.Client{Transport: reg}, nil
+			},
+		}
+	}
+
+	t.Run("success is cached", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		// A single stub: a second probe would fail as an unmatched request.
+		probeStub(reg, 200)
+		defer reg.Verify(t)
+
+		clientFunc := CapiClientFunc(newFactory(reg))
+
+		client, err := clientFunc()
+		require.NoError(t, err)
+		require.NotNil(t, client)
+
+		again, err := clientFunc()
+		require.NoError(t, err)
+		assert.Same(t, client, again)
+	})
+
+	t.Run("copilot not enabled is cached", func(t *testing.T) {
+		reg := &httpmock.Registry{}
+		probeStub(reg, 403)
+		defer reg.Verify(t)
+
+		clientFunc := CapiClientFunc(newFactory(reg))
+
+		_, err := clientFunc()
+		require.ErrorIs(t, err, capi.ErrCopilotNotEnabled)
+
+		_, err = clientFunc()
+		require.ErrorIs(t, err, capi.ErrCopilotNotEnabled)
+	})
+}
+
+func TestCapiClientFuncEventTypeOverride(t *testing.T) {
+	newFactory := func(reg *httpmock.Registry) *cmdutil.Factory {
+		return &cmdutil.Factory{
+			Config: func() (gh.Config, error) {
+				return config.NewBlankConfig(), nil
+			},
+			HttpClient: func() (*http.Client, error) {
+				return &http.Client{Transport: reg}, nil
+			},
+		}
+	}
+
+	t.Run("custom event type is sent on job creation", func(t *testing.T) {
+		t.Setenv("GH_AGENT_TASK_EVENT_TYPE", "my_integration")
+
+		reg := &httpmock.Registry{}
+		reg.Register(
+			httpmock.WithHost(
+				httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+					"page_number": {"1"},
+					"page_size":   {"1"},
+				}),
+				"api.githubcopilot.com",
+			),
+			httpmock.StatusStringResponse(200, `{}`),
+		)
+		reg.Register(
+			httpmock.WithHost(httpmock.REST("POST", "agents/swe/v1/jobs/OWNER/REPO"), "api.githubcopilot.com"),
+			httpmock.RESTPayload(201, `{"job_id": "job123"}`,
+				func(payload map[string]interface{}) {
+					assert.Equal(t, "my_integration", payload["event_type"])
+				},
+			),
+		)
+		defer reg.Verify(t)
+
+		client, err := CapiClientFunc(newFactory(reg))()
+		require.NoError(t, err)
+
+		_, err = client.CreateJob(context.Background(), "OWNER", "REPO", "Do the thing", "", nil, nil, 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("event type with whitespace is rejected", func(t *testing.T) {
+		t.Setenv("GH_AGENT_TASK_EVENT_TYPE", "not a token")
+
+		_, err := CapiClientFunc(newFactory(&httpmock.Registry{}))()
+		require.EqualError(t, err, `invalid GH_AGENT_TASK_EVENT_TYPE "not a token": must not contain whitespace`)
+	})
+}
+
+func TestCapiClientFuncTracesRequestsWhenDebugEnabled(t *testing.T) {
+	t.Setenv("GH_DEBUG", "1")
+
+	reg := &httpmock.Registry{}
+	reg.Register(
+		httpmock.WithHost(
+			httpmock.QueryMatcher("GET", "agents/sessions", url.Values{
+				"page_number": {"1"},
+				"page_size":   {"1"},
+			}),
+			"api.githubcopilot.com",
+		),
+		httpmock.StatusStringResponse(200, `{}`),
+	)
+	defer reg.Verify(t)
+
+	ios, _, _, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+		Config: func() (gh.Config, error) {
+			return config.NewBlankConfig(), nil
+		},
+		HttpClient: func() (*http.Client, error) {
+			return &http.Client{Transport: reg}, nil
+		},
+	}
+
+	_, err := CapiClientFunc(f)()
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr.String(), "* CAPI GET https://api.githubcopilot.com/agents/sessions?")
+	assert.Contains(t, stderr.String(), " 200 (")
+}
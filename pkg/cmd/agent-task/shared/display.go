@@ -5,17 +5,33 @@ import (
 	"github.com/cli/cli/v2/pkg/iostreams"
 )
 
+// Known agent session states, re-exported from the capi package where the
+// canonical state set lives alongside Session.IsTerminal.
+const (
+	SessionStateQueued         = capi.SessionStateQueued
+	SessionStateInProgress     = capi.SessionStateInProgress
+	SessionStateCompleted      = capi.SessionStateCompleted
+	SessionStateFailed         = capi.SessionStateFailed
+	SessionStateIdle           = capi.SessionStateIdle
+	SessionStateWaitingForUser = capi.SessionStateWaitingForUser
+	SessionStateTimedOut       = capi.SessionStateTimedOut
+	SessionStateCancelled      = capi.SessionStateCancelled
+	SessionStateCanceled       = capi.SessionStateCanceled
+)
+
 // ColorFuncForSessionState returns a function that colors the session state
 func ColorFuncForSessionState(s capi.Session, cs *iostreams.ColorScheme) func(string) string {
 	var stateColor func(string) string
 	switch s.State {
-	case "completed":
+	case SessionStateCompleted:
 		stateColor = cs.Green
-	case "cancelled":
+	case SessionStateCancelled, SessionStateCanceled, SessionStateIdle:
 		stateColor = cs.Muted
-	case "in_progress", "queued":
+	case SessionStateInProgress, SessionStateQueued:
 		stateColor = cs.Yellow
-	case "failed":
+	case SessionStateWaitingForUser:
+		stateColor = cs.Cyan
+	case SessionStateFailed, SessionStateTimedOut:
 		stateColor = cs.Red
 	default:
 		stateColor = cs.Muted
@@ -27,37 +43,68 @@ func ColorFuncForSessionState(s capi.Session, cs *iostreams.ColorScheme) func(st
 // SessionStateString returns the humane/capitalised form of the given session state.
 func SessionStateString(state string) string {
 	switch state {
-	case "queued":
+	case SessionStateQueued:
 		return "Queued"
-	case "in_progress":
+	case SessionStateInProgress:
 		return "In progress"
-	case "completed":
+	case SessionStateCompleted:
 		return "Ready for review"
-	case "failed":
+	case SessionStateFailed:
 		return "Failed"
-	case "idle":
+	case SessionStateIdle:
 		return "Idle"
-	case "waiting_for_user":
+	case SessionStateWaitingForUser:
 		return "Waiting for user"
-	case "timed_out":
+	case SessionStateTimedOut:
 		return "Timed out"
-	case "cancelled":
+	case SessionStateCancelled, SessionStateCanceled:
 		return "Cancelled"
 	default:
 		return state
 	}
 }
 
+// SessionStateIndicatesFailure reports whether the given session state
+// represents a failed outcome (i.e. the agent did not finish its work).
+func SessionStateIndicatesFailure(state string) bool {
+	switch state {
+	case SessionStateFailed, SessionStateTimedOut:
+		return true
+	}
+	return false
+}
+
 type ColorFunc func(string) string
 
+// SessionSymbol returns a colored glyph representing the given session state:
+// ✓ for completed, X for failed and timed-out, a muted X for cancelled, and a
+// colored ● for in-progress, queued, and waiting states. It covers the same
+// state set as ColorFuncForSessionState and SessionStateString, with unknown
+// states falling back to a muted dash.
 func SessionSymbol(cs *iostreams.ColorScheme, state string) string {
-	noColor := func(s string) string { return s }
+	return sessionSymbol(cs, state, "✓", "●")
+}
+
+// SessionSymbolASCII is SessionSymbol with ASCII stand-ins for the Unicode
+// glyphs ("+" for ✓ and "*" for ●), for terminals and CI logs that render
+// Unicode poorly.
+func SessionSymbolASCII(cs *iostreams.ColorScheme, state string) string {
+	return sessionSymbol(cs, state, "+", "*")
+}
+
+func sessionSymbol(cs *iostreams.ColorScheme, state, check, dot string) string {
 	switch state {
-	case "completed":
-		return cs.SuccessIconWithColor(noColor)
-	case "failed", "timed_out", "cancelled":
-		return cs.FailureIconWithColor(noColor)
+	case SessionStateCompleted:
+		return cs.Green(check)
+	case SessionStateFailed, SessionStateTimedOut:
+		return cs.FailureIcon()
+	case SessionStateCancelled, SessionStateCanceled:
+		return cs.FailureIconWithColor(cs.Muted)
+	case SessionStateInProgress, SessionStateQueued:
+		return cs.Yellow(dot)
+	case SessionStateWaitingForUser:
+		return cs.Cyan(dot)
 	default:
-		return "-"
+		return cs.Muted("-")
 	}
 }
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionStates(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	ios.SetColorEnabled(true)
+	cs := ios.ColorScheme()
+
+	tests := []struct {
+		state           string
+		wantString      string
+		wantColored     string
+		wantSymbol      string
+		wantSymbolASCII string
+		wantIsFailure   bool
+	}{
+		{
+			state:           "queued",
+			wantString:      "Queued",
+			wantColored:     cs.Yellow("Queued"),
+			wantSymbol:      cs.Yellow("●"),
+			wantSymbolASCII: cs.Yellow("*"),
+		},
+		{
+			state:           "in_progress",
+			wantString:      "In progress",
+			wantColored:     cs.Yellow("In progress"),
+			wantSymbol:      cs.Yellow("●"),
+			wantSymbolASCII: cs.Yellow("*"),
+		},
+		{
+			state:           "completed",
+			wantString:      "Ready for review",
+			wantColored:     cs.Green("Ready for review"),
+			wantSymbol:      cs.SuccessIcon(),
+			wantSymbolASCII: cs.Green("+"),
+		},
+		{
+			state:           "failed",
+			wantString:      "Failed",
+			wantColored:     cs.Red("Failed"),
+			wantSymbol:      cs.FailureIcon(),
+			wantSymbolASCII: cs.FailureIcon(),
+			wantIsFailure:   true,
+		},
+		{
+			state:           "idle",
+			wantString:      "Idle",
+			wantColored:     cs.Muted("Idle"),
+			wantSymbol:      cs.Muted("-"),
+			wantSymbolASCII: cs.Muted("-"),
+		},
+		{
+			state:           "waiting_for_user",
+			wantString:      "Waiting for user",
+			wantColored:     cs.Cyan("Waiting for user"),
+			wantSymbol:      cs.Cyan("●"),
+			wantSymbolASCII: cs.Cyan("*"),
+		},
+		{
+			state:           "timed_out",
+			wantString:      "Timed out",
+			wantColored:     cs.Red("Timed out"),
+			wantSymbol:      cs.FailureIcon(),
+			wantSymbolASCII: cs.FailureIcon(),
+			wantIsFailure:   true,
+		},
+		{
+			state:           "cancelled",
+			wantString:      "Cancelled",
+			wantColored:     cs.Muted("Cancelled"),
+			wantSymbol:      cs.FailureIconWithColor(cs.Muted),
+			wantSymbolASCII: cs.FailureIconWithColor(cs.Muted),
+		},
+		{
+			state:           "canceled",
+			wantString:      "Cancelled",
+			wantColored:     cs.Muted("Cancelled"),
+			wantSymbol:      cs.FailureIconWithColor(cs.Muted),
+			wantSymbolASCII: cs.FailureIconWithColor(cs.Muted),
+		},
+		{
+			state:           "some_unknown_state",
+			wantString:      "some_unknown_state",
+			wantColored:     cs.Muted("some_unknown_state"),
+			wantSymbol:      cs.Muted("-"),
+			wantSymbolASCII: cs.Muted("-"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			stateString := SessionStateString(tt.state)
+			assert.Equal(t, tt.wantString, stateString)
+
+			colorFunc := ColorFuncForSessionState(capi.Session{State: tt.state}, cs)
+			assert.Equal(t, tt.wantColored, colorFunc(stateString))
+
+			assert.Equal(t, tt.wantSymbol, SessionSymbol(cs, tt.state))
+
+			assert.Equal(t, tt.wantSymbolASCII, SessionSymbolASCII(cs, tt.state))
+
+			assert.Equal(t, tt.wantIsFailure, SessionStateIndicatesFailure(tt.state))
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package shared
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/cli/cli/v2/pkg/markdown"
@@ -16,79 +19,263 @@ import (
 //go:generate moq -rm -out log_mock.go . LogRenderer
 
 type LogRenderer interface {
-	Follow(fetcher func() ([]byte, error), w io.Writer, io *iostreams.IOStreams) error
-	Render(logs []byte, w io.Writer, io *iostreams.IOStreams) (stop bool, err error)
+	Follow(fetcher LogFetcher, w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) error
+	FollowReader(fetcher func() (io.ReadCloser, error), w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) error
+	Render(logs []byte, w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) (stop bool, err error)
 }
 
-type logRenderer struct{}
+// LogEntryFilter decides whether a log entry should be rendered. A nil
+// filter renders every entry.
+type LogEntryFilter func(entry chatCompletionChunkEntry) bool
 
-func NewLogRenderer() LogRenderer {
-	return &logRenderer{}
+// SinceLogEntryFilter returns a LogEntryFilter that skips log entries
+// created before the given cutoff time.
+func SinceLogEntryFilter(cutoff time.Time) LogEntryFilter {
+	return func(entry chatCompletionChunkEntry) bool {
+		return !time.Unix(entry.Created, 0).Before(cutoff)
+	}
 }
 
+// How many consecutive fetch failures Follow and FollowReader tolerate before
+// giving up, and how long to wait before retrying after one. A transient
+// network blip shouldn't kill a session tail, while a persistent failure
+// still surfaces.
+const (
+	defaultMaxFetchRetries   = 3
+	defaultFetchRetryBackoff = 2 * time.Second
+)
+
+type logRenderer struct {
+	// lastPRDescription holds the most recently rendered report_progress PR
+	// description. Progress updates tend to repeat the full description
+	// verbatim, so unchanged descriptions are collapsed to just their commit
+	// message. This is particularly helpful in follow mode, where every
+	// update would otherwise re-print the same long markdown block.
+	lastPRDescription string
+
+	maxFetchRetries   int
+	fetchRetryBackoff time.Duration
+	sleep             func(time.Duration)
+
+	// hideReasoning suppresses the agent's reasoning and thought entries,
+	// leaving only the main output and tool calls in the transcript.
+	hideReasoning bool
+}
+
+// A LogRendererOption customizes the renderer returned by NewLogRenderer.
+type LogRendererOption func(*logRenderer)
+
+// WithHiddenReasoning suppresses the agent's reasoning and thought entries
+// for a cleaner transcript.
+func WithHiddenReasoning() LogRendererOption {
+	return func(r *logRenderer) {
+		r.hideReasoning = true
+	}
+}
+
+func NewLogRenderer(opts ...LogRendererOption) LogRenderer {
+	r := NewLogRendererWithRetry(defaultMaxFetchRetries, defaultFetchRetryBackoff).(*logRenderer)
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// NewLogRendererWithRetry returns a LogRenderer whose follow modes tolerate
+// up to maxRetries consecutive fetch failures, waiting backoff between
+// attempts. A maxRetries of zero fails on the first fetch error.
+func NewLogRendererWithRetry(maxRetries int, backoff time.Duration) LogRenderer {
+	return &logRenderer{
+		maxFetchRetries:   maxRetries,
+		fetchRetryBackoff: backoff,
+		sleep:             time.Sleep,
+	}
+}
+
+// A LogFetcher returns log content for one follow poll. The offset is the
+// number of bytes already rendered; a fetcher that can serve ranges returns
+// only the bytes from that offset with partial set, while one that always
+// fetches the whole log returns it with partial unset and Follow slices off
+// the already-rendered prefix itself.
+type LogFetcher func(offset int64) (raw []byte, partial bool, err error)
+
 // Follow continuously fetches logs using the provided fetcher function and
 // renders them to the provided writer. It stops when Render indicates to stop.
-func (r *logRenderer) Follow(fetcher func() ([]byte, error), w io.Writer, io *iostreams.IOStreams) error {
-	var last string
+func (r *logRenderer) Follow(fetcher LogFetcher, w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) error {
+	// Bytes already rendered by previous fetches.
+	var offset int64
+	var consecutiveErrs int
 	for {
-		raw, err := fetcher()
+		raw, partial, err := fetcher(offset)
 		if err != nil {
-			return err
+			consecutiveErrs++
+			if consecutiveErrs > r.maxFetchRetries {
+				return err
+			}
+			r.sleep(r.fetchRetryBackoff)
+			continue
 		}
+		consecutiveErrs = 0
 
 		logs := string(raw)
-		if logs == last {
+		if !partial {
+			// Full log; drop the prefix that has already been rendered.
+			if int64(len(logs)) <= offset {
+				continue
+			}
+			logs = logs[offset:]
+		}
+		if logs == "" {
 			continue
 		}
+		offset += int64(len(logs))
 
-		diff := strings.TrimSpace(logs[len(last):])
+		diff := strings.TrimSpace(logs)
 
-		if stop, err := r.Render([]byte(diff), w, io); err != nil {
+		if stop, err := r.Render([]byte(diff), w, io, filter); err != nil {
 			return err
 		} else if stop {
 			return nil
 		}
+	}
+}
+
+// FollowReader is the streaming counterpart of Follow: each fetch returns a
+// reader over the whole log, which is consumed incrementally instead of being
+// buffered in memory. It stops when a rendered entry indicates to stop.
+func (r *logRenderer) FollowReader(fetcher func() (io.ReadCloser, error), w io.Writer, ios *iostreams.IOStreams, filter LogEntryFilter) error {
+	// Bytes already rendered by previous fetches; every fetch restarts from
+	// the beginning of the log, so this much of each stream is skipped.
+	var offset int64
+	var consecutiveErrs int
+	for {
+		rc, err := fetcher()
+		if err != nil {
+			consecutiveErrs++
+			if consecutiveErrs > r.maxFetchRetries {
+				return err
+			}
+			r.sleep(r.fetchRetryBackoff)
+			continue
+		}
+		consecutiveErrs = 0
+
+		stop, n, err := r.renderStream(rc, offset, w, ios, filter)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+
+		offset += n
+		if stop {
+			return nil
+		}
+	}
+}
+
+// renderStream discards the first offset bytes of rc and renders the complete
+// lines that follow, returning how many bytes were consumed. A trailing
+// partial line is left for the next fetch, when the rest of it has arrived.
+func (r *logRenderer) renderStream(rc io.Reader, offset int64, w io.Writer, ios *iostreams.IOStreams, filter LogEntryFilter) (bool, int64, error) {
+	if _, err := io.CopyN(io.Discard, rc, offset); err != nil {
+		if errors.Is(err, io.EOF) {
+			// The log is no longer than what has already been rendered.
+			return false, 0, nil
+		}
+		return false, 0, err
+	}
+
+	var consumed int64
+	br := bufio.NewReader(rc)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, consumed, nil
+			}
+			return false, consumed, err
+		}
+
+		consumed += int64(len(line))
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
 
-		last = logs
+		if stop, err := r.renderLogLine(line, w, ios, filter); err != nil {
+			return false, consumed, err
+		} else if stop {
+			return true, consumed, nil
+		}
 	}
 }
 
 // Render processes the given logs and writes the rendered output to w.
 // Errors are returned when an unexpected log entry is encountered.
-func (r *logRenderer) Render(logs []byte, w io.Writer, io *iostreams.IOStreams) (bool, error) {
+func (r *logRenderer) Render(logs []byte, w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) (bool, error) {
 	lines := slices.DeleteFunc(strings.Split(string(logs), "\n"), func(line string) bool {
 		return line == ""
 	})
 
 	for _, line := range lines {
-		raw, found := strings.CutPrefix(line, "data: ")
-		if !found {
-			return false, errors.New("unexpected log format")
+		if stop, err := r.renderLogLine(line, w, io, filter); err != nil {
+			return false, err
+		} else if stop {
+			return true, nil
 		}
+	}
 
-		// The only log entry type we're interested in is a chat completion chunk,
-		// which can be verified by a successful unmarshal into the corresponding
-		// type AND the Object field being equal to "chat.completion.chunk". The
-		// latter is to avoid accepting an empty JSON object (i.e. "{}"). Also,
-		// if the entry is not what we expect, we should just skip and avoid
-		// returning an error.
-		var entry chatCompletionChunkEntry
-		err := json.Unmarshal([]byte(raw), &entry)
-		if err != nil || entry.Object != "chat.completion.chunk" {
-			continue
-		}
+	return false, nil
+}
 
-		if stop, err := renderLogEntry(entry, w, io); err != nil {
-			return false, fmt.Errorf("failed to process log entry: %w", err)
-		} else if stop {
+// renderLogLine processes a single "data: "-prefixed log line.
+func (r *logRenderer) renderLogLine(line string, w io.Writer, io *iostreams.IOStreams, filter LogEntryFilter) (bool, error) {
+	raw, found := strings.CutPrefix(line, "data: ")
+	if !found {
+		return false, errors.New("unexpected log format")
+	}
+
+	// The only log entry type we're interested in is a chat completion chunk,
+	// which can be verified by a successful unmarshal into the corresponding
+	// type AND the Object field being equal to "chat.completion.chunk". The
+	// latter is to avoid accepting an empty JSON object (i.e. "{}"). Also,
+	// if the entry is not what we expect, we should just skip and avoid
+	// returning an error.
+	var entry chatCompletionChunkEntry
+	err := json.Unmarshal([]byte(raw), &entry)
+	if err != nil || entry.Object != "chat.completion.chunk" {
+		return false, nil
+	}
+
+	if filter != nil && !filter(entry) {
+		// Skip rendering, but still honor the stop marker so that
+		// Follow terminates even when the tail is filtered out.
+		if entryIndicatesStop(entry) {
 			return true, nil
 		}
+		return false, nil
+	}
+
+	if stop, err := r.renderLogEntry(entry, w, io); err != nil {
+		return false, fmt.Errorf("failed to process log entry: %w", err)
+	} else if stop {
+		return true, nil
 	}
 
 	return false, nil
 }
 
-func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.IOStreams) (bool, error) {
+// entryIndicatesStop reports whether any choice in the entry carries the
+// "stop" finish reason.
+func entryIndicatesStop(entry chatCompletionChunkEntry) bool {
+	for _, choice := range entry.Choices {
+		if choice.FinishReason == "stop" {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *logRenderer) renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.IOStreams) (bool, error) {
 	cs := io.ColorScheme()
 	var stop bool
 	for _, choice := range entry.Choices {
@@ -111,10 +298,8 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 			continue
 		}
 
-		if choice.Delta.ReasoningText != "" {
-			// Note that this should be formatted as a normal "thought" message,
-			// without the heading.
-			renderRawMarkdown(choice.Delta.ReasoningText, w, io)
+		if choice.Delta.ReasoningText != "" && !r.hideReasoning {
+			renderThought(choice.Delta.ReasoningText, w, io)
 		}
 
 		for _, tc := range choice.Delta.ToolCalls {
@@ -139,11 +324,14 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 				}
 				renderToolCallTitle(w, cs, fmt.Sprintf("View %s", cs.Bold(relativeFilePath(args.Path))), "")
 
-				content := stripDiffFormat(choice.Delta.Content)
+				if err := renderDiffContent(choice.Delta.Content, w, io); err != nil {
+					// Not a diff; render the file content as a code block.
+					content := stripDiffFormat(choice.Delta.Content)
 
-				if err := renderFileContentAsMarkdown(args.Path, content, w, io); err != nil {
-					fmt.Fprintf(io.ErrOut, "\nfailed to render viewed file content: %v\n\n", err)
-					fmt.Fprintln(io.ErrOut, content) // raw fallback
+					if err := renderFileContentAsMarkdown(args.Path, content, w, io); err != nil {
+						fmt.Fprintf(io.ErrOut, "\nfailed to render viewed file content: %v\n\n", err)
+						fmt.Fprintln(io.ErrOut, content) // raw fallback
+					}
 				}
 			case "bash":
 				if v := unmarshal[bashToolArgs](args); v != nil {
@@ -194,6 +382,10 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 					continue
 				}
 			case "think":
+				if r.hideReasoning {
+					continue
+				}
+
 				args := thinkToolArgs{}
 				if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err != nil {
 					fmt.Fprintf(io.ErrOut, "\nfailed to parse 'think' tool call arguments: %v\n", err)
@@ -201,8 +393,7 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 				}
 
 				// NOTE: omit the delta.content since it's the same as thought
-				renderToolCallTitle(w, cs, "Thought", "")
-				if err := renderRawMarkdown(args.Thought, w, io); err != nil {
+				if err := renderThought(args.Thought, w, io); err != nil {
 					fmt.Fprintf(io.ErrOut, "\nfailed to render thought: %v\n", err)
 				}
 			case "report_progress":
@@ -213,10 +404,11 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 				}
 
 				renderToolCallTitle(w, cs, "Progress update", cs.Bold(args.CommitMessage))
-				if args.PrDescription != "" {
+				if args.PrDescription != "" && args.PrDescription != r.lastPRDescription {
 					if err := renderRawMarkdown(args.PrDescription, w, io); err != nil {
 						fmt.Fprintf(io.ErrOut, "\nfailed to render PR description: %v\n", err)
 					}
+					r.lastPRDescription = args.PrDescription
 				}
 
 				// TODO: KW I wasn't able to get this case to populate ever.
@@ -247,9 +439,12 @@ func renderLogEntry(entry chatCompletionChunkEntry, w io.Writer, io *iostreams.I
 				}
 
 				renderToolCallTitle(w, cs, "Edit", cs.Bold(relativeFilePath(args.Path)))
-				if err := renderFileContentAsMarkdown("output.diff", choice.Delta.Content, w, io); err != nil {
-					fmt.Fprintf(io.ErrOut, "\nfailed to render str_replace diff: %v\n\n", err)
-					fmt.Fprintln(io.ErrOut, choice.Delta.Content)
+				if err := renderDiffContent(choice.Delta.Content, w, io); err != nil {
+					// Not a diff; fall back to the generic code block rendering.
+					if err := renderFileContentAsMarkdown("output.diff", choice.Delta.Content, w, io); err != nil {
+						fmt.Fprintf(io.ErrOut, "\nfailed to render str_replace diff: %v\n\n", err)
+						fmt.Fprintln(io.ErrOut, choice.Delta.Content)
+					}
 				}
 			default:
 				// Unknown tool call. For example for "codeql_checker":
@@ -293,6 +488,16 @@ func renderContentAsJSONMarkdown(label, content string, w io.Writer, io *iostrea
 	return nil
 }
 
+// renderThought renders reasoning content under a muted "💭 Thought" header so
+// it reads apart from the agent's main output.
+func renderThought(thought string, w io.Writer, io *iostreams.IOStreams) error {
+	cs := io.ColorScheme()
+	fmt.Fprintf(w, "%s\n", cs.Muted("💭 Thought"))
+	return renderMarkdownWithFormat(thought, w, io, func(s string) string {
+		return fmt.Sprintf("\n%s\n\n", cs.Muted(s))
+	})
+}
+
 // renderRawMarkdown renders the given raw markdown string to the given writer.
 // Use for complete markdown content from tool calls that need no conversion.
 func renderRawMarkdown(md string, w io.Writer, io *iostreams.IOStreams) error {
@@ -305,6 +510,10 @@ func renderRawMarkdown(md string, w io.Writer, io *iostreams.IOStreams) error {
 	return renderMarkdownWithFormat(md, w, io, formatFunc)
 }
 
+// ansiRE matches ANSI CSI sequences (colors, cursor movement) and OSC
+// sequences (hyperlinks) so they can be stripped from rendered markdown.
+var ansiRE = regexp.MustCompile("\x1b(?:\\[[0-9;?]*[@-~]|\\][^\x07\x1b]*(?:\x07|\x1b\\\\))")
+
 // renderMarkdownWithFormat renders the given markdown string to the given writer.
 // If a formatFunc is provided, the md string is ran through it before
 // rendering. This can be used to add newlines before and after the content.
@@ -318,6 +527,14 @@ func renderMarkdownWithFormat(md string, w io.Writer, io *iostreams.IOStreams, f
 		return fmt.Errorf("failed to render markdown: %w", err)
 	}
 
+	// Glamour honors GLAMOUR_STYLE over the detected theme, so it can emit
+	// escape sequences even when color is disabled (NO_COLOR, piped output).
+	// The rest of the renderer goes through the color scheme, which already
+	// no-ops in that case; make the markdown path behave the same way.
+	if !io.ColorEnabled() {
+		rendered = ansiRE.ReplaceAllString(rendered, "")
+	}
+
 	rendered = strings.TrimSpace(rendered)
 	if formatFunc != nil {
 		rendered = formatFunc(rendered)
@@ -362,6 +579,44 @@ func stripDiffFormat(diff string) string {
 	return strings.Join(stripped, "\n")
 }
 
+// renderDiffContent renders a unified diff with added lines in green, removed
+// lines in red, and hunk headers muted, using the color scheme directly
+// instead of glamour's generic code block. It returns an error when the
+// content does not contain a hunk header, so callers can fall back to their
+// existing rendering.
+func renderDiffContent(diff string, w io.Writer, io *iostreams.IOStreams) error {
+	lines := strings.Split(strings.Trim(diff, "\n"), "\n")
+
+	if !slices.ContainsFunc(lines, func(line string) bool {
+		return strings.HasPrefix(line, "@@")
+	}) {
+		return errors.New("content does not contain a diff hunk header")
+	}
+
+	cs := io.ColorScheme()
+	var b strings.Builder
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+			b.WriteString(cs.Bold(line))
+		case strings.HasPrefix(line, "@@"):
+			b.WriteString(cs.Muted(line))
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(cs.Green(line))
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(cs.Red(line))
+		default:
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	// Code blocks rendered through glamour are surrounded by blank lines;
+	// match that spacing so diffs sit flush with the rest of the output.
+	fmt.Fprintf(w, "\n%s\n", b.String())
+	return nil
+}
+
 // renderFileContentAsMarkdown renders the given content as markdown
 // based on the file extension of the path.
 func renderFileContentAsMarkdown(path, content string, w io.Writer, io *iostreams.IOStreams) error {
@@ -381,23 +636,32 @@ func renderFileContentAsMarkdown(path, content string, w io.Writer, io *iostream
 	return renderMarkdownWithFormat(md, w, io, formatFunc)
 }
 
-// relativeFilePath converts an absolute file path to a relative one.
-// We expect paths to be of the form: /home/runner/work/<repo-owner>/<repo-name>/path/to/file
-// The expected output of that example is: path/to/file
+// relativeFilePath converts an absolute file path from a runner workspace to
+// a repo-relative one.
+//
+// Hosted runners check the repository out under
+// /home/runner/work/<repo-owner>/<repo-name>/, while self-hosted runners use
+// <runner-root>/_work/<repo-owner>/<repo-name>/. The workspace root is
+// located by the first "work" or "_work" segment in the path, so custom
+// runner roots are handled as long as they keep that layout. Paths with no
+// recognizable workspace root are returned unchanged rather than mangled.
 func relativeFilePath(absPath string) string {
-	relPath := strings.TrimPrefix(absPath, "/home/runner/work/")
-
-	parts := strings.Split(relPath, "/")
+	parts := strings.Split(absPath, "/")
+	for i, part := range parts {
+		if part != "work" && part != "_work" {
+			continue
+		}
 
-	// The last two parts of the path are the
-	// repo name and the repo owner.
-	// If that's all we have (or less),
-	// we return a friendly name "repository".
-	if len(parts) > 2 {
-		// Drop the repo owner and name, returning the remaining path.
-		return strings.Join(parts[2:], "/")
+		// The two segments after the workspace root are the repo owner and
+		// name; anything after those is the repo-relative path. When the path
+		// stops at the checkout itself, return a friendly name instead.
+		rest := parts[i+1:]
+		if len(rest) > 2 {
+			return strings.Join(rest[2:], "/")
+		}
+		return "repository"
 	}
-	return "repository"
+	return absPath
 }
 
 func unmarshal[T any](raw string) *T {
@@ -19,10 +19,13 @@ var _ LogRenderer = &LogRendererMock{}
 //
 //		// make and configure a mocked LogRenderer
 //		mockedLogRenderer := &LogRendererMock{
-//			FollowFunc: func(fetcher func() ([]byte, error), w io.Writer, ioMoqParam *iostreams.IOStreams) error {
+//			FollowFunc: func(fetcher LogFetcher, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error {
 //				panic("mock out the Follow method")
 //			},
-//			RenderFunc: func(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams) (bool, error) {
+//			FollowReaderFunc: func(fetcher func() (io.ReadCloser, error), w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error {
+//				panic("mock out the FollowReader method")
+//			},
+//			RenderFunc: func(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) (bool, error) {
 //				panic("mock out the Render method")
 //			},
 //		}
@@ -33,21 +36,37 @@ var _ LogRenderer = &LogRendererMock{}
 //	}
 type LogRendererMock struct {
 	// FollowFunc mocks the Follow method.
-	FollowFunc func(fetcher func() ([]byte, error), w io.Writer, ioMoqParam *iostreams.IOStreams) error
+	FollowFunc func(fetcher LogFetcher, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error
+
+	// FollowReaderFunc mocks the FollowReader method.
+	FollowReaderFunc func(fetcher func() (io.ReadCloser, error), w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error
 
 	// RenderFunc mocks the Render method.
-	RenderFunc func(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams) (bool, error)
+	RenderFunc func(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) (bool, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
 		// Follow holds details about calls to the Follow method.
 		Follow []struct {
 			// Fetcher is the fetcher argument value.
-			Fetcher func() ([]byte, error)
+			Fetcher LogFetcher
+			// W is the w argument value.
+			W io.Writer
+			// IoMoqParam is the ioMoqParam argument value.
+			IoMoqParam *iostreams.IOStreams
+			// Filter is the filter argument value.
+			Filter LogEntryFilter
+		}
+		// FollowReader holds details about calls to the FollowReader method.
+		FollowReader []struct {
+			// Fetcher is the fetcher argument value.
+			Fetcher func() (io.ReadCloser, error)
 			// W is the w argument value.
 			W io.Writer
 			// IoMoqParam is the ioMoqParam argument value.
 			IoMoqParam *iostreams.IOStreams
+			// Filter is the filter argument value.
+			Filter LogEntryFilter
 		}
 		// Render holds details about calls to the Render method.
 		Render []struct {
@@ -57,30 +76,35 @@ type LogRendererMock struct {
 			W io.Writer
 			// IoMoqParam is the ioMoqParam argument value.
 			IoMoqParam *iostreams.IOStreams
+			// Filter is the filter argument value.
+			Filter LogEntryFilter
 		}
 	}
-	lockFollow sync.RWMutex
-	lockRender sync.RWMutex
+	lockFollow       sync.RWMutex
+	lockFollowReader sync.RWMutex
+	lockRender       sync.RWMutex
 }
 
 // Follow calls FollowFunc.
-func (mock *LogRendererMock) Follow(fetcher func() ([]byte, error), w io.Writer, ioMoqParam *iostreams.IOStreams) error {
+func (mock *LogRendererMock) Follow(fetcher LogFetcher, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error {
 	if mock.FollowFunc == nil {
 		panic("LogRendererMock.FollowFunc: method is nil but LogRenderer.Follow was just called")
 	}
 	callInfo := struct {
-		Fetcher    func() ([]byte, error)
+		Fetcher    LogFetcher
 		W          io.Writer
 		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
 	}{
 		Fetcher:    fetcher,
 		W:          w,
 		IoMoqParam: ioMoqParam,
+		Filter:     filter,
 	}
 	mock.lockFollow.Lock()
 	mock.calls.Follow = append(mock.calls.Follow, callInfo)
 	mock.lockFollow.Unlock()
-	return mock.FollowFunc(fetcher, w, ioMoqParam)
+	return mock.FollowFunc(fetcher, w, ioMoqParam, filter)
 }
 
 // FollowCalls gets all the calls that were made to Follow.
@@ -88,14 +112,16 @@ func (mock *LogRendererMock) Follow(fetcher func() ([]byte, error), w io.Writer,
 //
 //	len(mockedLogRenderer.FollowCalls())
 func (mock *LogRendererMock) FollowCalls() []struct {
-	Fetcher    func() ([]byte, error)
+	Fetcher    LogFetcher
 	W          io.Writer
 	IoMoqParam *iostreams.IOStreams
+	Filter     LogEntryFilter
 } {
 	var calls []struct {
-		Fetcher    func() ([]byte, error)
+		Fetcher    LogFetcher
 		W          io.Writer
 		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
 	}
 	mock.lockFollow.RLock()
 	calls = mock.calls.Follow
@@ -103,8 +129,52 @@ func (mock *LogRendererMock) FollowCalls() []struct {
 	return calls
 }
 
+// FollowReader calls FollowReaderFunc.
+func (mock *LogRendererMock) FollowReader(fetcher func() (io.ReadCloser, error), w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) error {
+	if mock.FollowReaderFunc == nil {
+		panic("LogRendererMock.FollowReaderFunc: method is nil but LogRenderer.FollowReader was just called")
+	}
+	callInfo := struct {
+		Fetcher    func() (io.ReadCloser, error)
+		W          io.Writer
+		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
+	}{
+		Fetcher:    fetcher,
+		W:          w,
+		IoMoqParam: ioMoqParam,
+		Filter:     filter,
+	}
+	mock.lockFollowReader.Lock()
+	mock.calls.FollowReader = append(mock.calls.FollowReader, callInfo)
+	mock.lockFollowReader.Unlock()
+	return mock.FollowReaderFunc(fetcher, w, ioMoqParam, filter)
+}
+
+// FollowReaderCalls gets all the calls that were made to FollowReader.
+// Check the length with:
+//
+//	len(mockedLogRenderer.FollowReaderCalls())
+func (mock *LogRendererMock) FollowReaderCalls() []struct {
+	Fetcher    func() (io.ReadCloser, error)
+	W          io.Writer
+	IoMoqParam *iostreams.IOStreams
+	Filter     LogEntryFilter
+} {
+	var calls []struct {
+		Fetcher    func() (io.ReadCloser, error)
+		W          io.Writer
+		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
+	}
+	mock.lockFollowReader.RLock()
+	calls = mock.calls.FollowReader
+	mock.lockFollowReader.RUnlock()
+	return calls
+}
+
 // Render calls RenderFunc.
-func (mock *LogRendererMock) Render(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams) (bool, error) {
+func (mock *LogRendererMock) Render(logs []byte, w io.Writer, ioMoqParam *iostreams.IOStreams, filter LogEntryFilter) (bool, error) {
 	if mock.RenderFunc == nil {
 		panic("LogRendererMock.RenderFunc: method is nil but LogRenderer.Render was just called")
 	}
@@ -112,15 +182,17 @@ func (mock *LogRendererMock) Render(logs []byte, w io.Writer, ioMoqParam *iostre
 		Logs       []byte
 		W          io.Writer
 		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
 	}{
 		Logs:       logs,
 		W:          w,
 		IoMoqParam: ioMoqParam,
+		Filter:     filter,
 	}
 	mock.lockRender.Lock()
 	mock.calls.Render = append(mock.calls.Render, callInfo)
 	mock.lockRender.Unlock()
-	return mock.RenderFunc(logs, w, ioMoqParam)
+	return mock.RenderFunc(logs, w, ioMoqParam, filter)
 }
 
 // RenderCalls gets all the calls that were made to Render.
@@ -131,11 +203,13 @@ func (mock *LogRendererMock) RenderCalls() []struct {
 	Logs       []byte
 	W          io.Writer
 	IoMoqParam *iostreams.IOStreams
+	Filter     LogEntryFilter
 } {
 	var calls []struct {
 		Logs       []byte
 		W          io.Writer
 		IoMoqParam *iostreams.IOStreams
+		Filter     LogEntryFilter
 	}
 	mock.lockRender.RLock()
 	calls = mock.calls.Render
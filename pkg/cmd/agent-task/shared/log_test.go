@@ -1,10 +1,13 @@
 package shared
 
 import (
+	"errors"
+	"io"
 	"os"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +18,8 @@ func TestFollow(t *testing.T) {
 	tests := []struct {
 		name           string
 		log            string
+		filter         LogEntryFilter
+		rendererOpts   []LogRendererOption
 		wantStdoutFile string
 		wantStderrFile string
 	}{
@@ -34,6 +39,19 @@ func TestFollow(t *testing.T) {
 			wantStdoutFile: "testdata/log-3-synthetic-failures-want.txt",
 			wantStderrFile: "testdata/log-3-synthetic-failures-want-stderr.txt",
 		},
+		{
+			name:           "sample log 3 (hidden reasoning)",
+			log:            "testdata/log-3-synthetic-failures-input.txt",
+			rendererOpts:   []LogRendererOption{WithHiddenReasoning()},
+			wantStdoutFile: "testdata/log-3-synthetic-failures-want-hidden-reasoning.txt",
+			wantStderrFile: "testdata/log-3-synthetic-failures-want-hidden-reasoning-stderr.txt",
+		},
+		{
+			name:           "sample log 4 (since filter skips old entries)",
+			log:            "testdata/log-4-timestamps-input.txt",
+			filter:         SinceLogEntryFilter(time.Unix(1700000150, 0)),
+			wantStdoutFile: "testdata/log-4-timestamps-want.txt",
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,17 +67,18 @@ func TestFollow(t *testing.T) {
 			})
 
 			var hits int
-			fetcher := func() ([]byte, error) {
+			fetcher := func(offset int64) ([]byte, bool, error) {
 				hits++
 				if hits > len(lines) {
 					require.FailNow(t, "too many API calls")
 				}
-				return []byte(strings.Join(lines[0:hits], "\n\n")), nil
+				// Full log each time; Follow slices off the rendered prefix.
+				return []byte(strings.Join(lines[0:hits], "\n\n")), false, nil
 			}
 
 			ios, _, stdout, stderr := iostreams.Test()
 
-			err = NewLogRenderer().Follow(fetcher, stdout, ios)
+			err = NewLogRenderer(tt.rendererOpts...).Follow(fetcher, stdout, ios, tt.filter)
 			require.NoError(t, err)
 
 			// Handy note for updating the testdata files when they change:
@@ -93,3 +112,256 @@ func TestFollow(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCollapsesRepeatedProgressDescriptions(t *testing.T) {
+	entry := func(commitMessage string) string {
+		return `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":" ","tool_calls":[{"function":{"name":"report_progress","arguments":"{\"commitMessage\":\"` + commitMessage + `\",\"prDescription\":\"Add the OS info script\"}"}}]}}]}`
+	}
+	logs := entry("First pass") + "\n" + entry("Second pass")
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	stop, err := NewLogRenderer().Render([]byte(logs), stdout, ios, nil)
+	require.NoError(t, err)
+	assert.False(t, stop)
+
+	assert.Contains(t, stdout.String(), "First pass")
+	assert.Contains(t, stdout.String(), "Second pass")
+	assert.Equal(t, 1, strings.Count(stdout.String(), "Add the OS info script"),
+		"expected the unchanged PR description to be rendered only once")
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestRenderDiffContent(t *testing.T) {
+	raw, err := os.ReadFile("testdata/diff-1-input.txt")
+	require.NoError(t, err)
+
+	// Normalize CRLF to LF to make the tests OS-agnostic.
+	diff := strings.ReplaceAll(string(raw), "\r\n", "\n")
+
+	ios, _, stdout, _ := iostreams.Test()
+	ios.SetColorEnabled(true)
+
+	require.NoError(t, renderDiffContent(diff, stdout, ios))
+
+	// Handy note for regenerating the testdata file when the rendering changes:
+	// os.WriteFile("testdata/diff-1-want.txt", stdout.Bytes(), 0644)
+
+	wantRaw, err := os.ReadFile("testdata/diff-1-want.txt")
+	require.NoError(t, err)
+
+	// Normalize CRLF to LF to make the tests OS-agnostic.
+	want := strings.ReplaceAll(string(wantRaw), "\r\n", "\n")
+
+	assert.Equal(t, want, stdout.String())
+}
+
+func TestRenderDiffContentRejectsNonDiffContent(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+
+	err := renderDiffContent("just some file content\nwith no hunks", stdout, ios)
+	require.EqualError(t, err, "content does not contain a diff hunk header")
+	assert.Empty(t, stdout.String())
+}
+
+func TestFollowSkipsEmptyInitialFetch(t *testing.T) {
+	entry := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"all done","role":"assistant"},"finish_reason":"stop"}]}`
+	responses := [][]byte{[]byte(""), []byte(entry)}
+
+	var hits int
+	fetcher := func(offset int64) ([]byte, bool, error) {
+		require.Less(t, hits, len(responses), "too many API calls")
+		raw := responses[hits]
+		hits++
+		return raw, false, nil
+	}
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	err := NewLogRenderer().Follow(fetcher, stdout, ios, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout.String(), "all done")
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestFollowConsumesPartialFetchesIncrementally(t *testing.T) {
+	entryOne := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"first message","role":"assistant"}}]}`
+	entryTwo := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"all done","role":"assistant"},"finish_reason":"stop"}]}`
+
+	var hits int
+	fetcher := func(offset int64) ([]byte, bool, error) {
+		hits++
+		switch hits {
+		case 1:
+			assert.EqualValues(t, 0, offset)
+			return []byte(entryOne), true, nil
+		case 2:
+			assert.EqualValues(t, len(entryOne), offset, "expected the offset to advance past the first chunk")
+			return []byte("\n\n" + entryTwo), true, nil
+		default:
+			require.FailNow(t, "too many API calls")
+			return nil, false, nil
+		}
+	}
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	err := NewLogRenderer().Follow(fetcher, stdout, ios, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, strings.Count(stdout.String(), "first message"))
+	assert.Equal(t, 1, strings.Count(stdout.String(), "all done"))
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestFollowRetriesTransientFetchErrors(t *testing.T) {
+	entry := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"all done","role":"assistant"},"finish_reason":"stop"}]}`
+
+	var hits int
+	fetcher := func(offset int64) ([]byte, bool, error) {
+		hits++
+		switch hits {
+		case 1:
+			return nil, false, errors.New("transient network error")
+		case 2:
+			return []byte(entry), false, nil
+		default:
+			require.FailNow(t, "too many API calls")
+			return nil, false, nil
+		}
+	}
+
+	renderer := NewLogRendererWithRetry(3, 0).(*logRenderer)
+	var sleeps int
+	renderer.sleep = func(time.Duration) { sleeps++ }
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	err := renderer.Follow(fetcher, stdout, ios, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 1, sleeps, "expected a backoff sleep before the retry")
+	assert.Contains(t, stdout.String(), "all done")
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestFollowGivesUpAfterConsecutiveFetchErrors(t *testing.T) {
+	fetchErr := errors.New("persistent network error")
+
+	var hits int
+	fetcher := func(offset int64) ([]byte, bool, error) {
+		hits++
+		return nil, false, fetchErr
+	}
+
+	renderer := NewLogRendererWithRetry(2, 0).(*logRenderer)
+	renderer.sleep = func(time.Duration) {}
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	err := renderer.Follow(fetcher, stdout, ios, nil)
+	require.ErrorIs(t, err, fetchErr)
+
+	assert.Equal(t, 3, hits, "expected the initial fetch plus two retries")
+	assert.Empty(t, stdout.String())
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestRenderEmitsNoEscapeCodesWhenColorDisabled(t *testing.T) {
+	// GLAMOUR_STYLE overrides the detected terminal theme inside glamour, so
+	// without the explicit guard this would leak ANSI sequences into
+	// color-disabled output.
+	t.Setenv("GLAMOUR_STYLE", "dark")
+
+	raw, err := os.ReadFile("testdata/log-1-input.txt")
+	require.NoError(t, err)
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	stop, err := NewLogRenderer().Render(raw, stdout, ios, nil)
+	require.NoError(t, err)
+	assert.True(t, stop)
+
+	assert.NotEmpty(t, stdout.String())
+	assert.NotContains(t, stdout.String(), "\x1b", "expected no escape codes in color-disabled output")
+	assert.NotContains(t, stderr.String(), "\x1b", "expected no escape codes in color-disabled output")
+}
+
+func TestFollowReaderConsumesStreamsIncrementally(t *testing.T) {
+	entryOne := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"first message","role":"assistant"}}]}`
+	entryTwo := `data: {"object":"chat.completion.chunk","choices":[{"delta":{"content":"all done","role":"assistant"},"finish_reason":"stop"}]}`
+
+	// Every fetch returns the log from the beginning. The second fetch ends
+	// in a partial line, which must be held back until the third fetch
+	// completes it; the already-rendered first entry must not repeat.
+	responses := []string{
+		entryOne + "\n",
+		entryOne + "\n" + entryTwo[:10],
+		entryOne + "\n" + entryTwo + "\n",
+	}
+
+	var hits int
+	fetcher := func() (io.ReadCloser, error) {
+		require.Less(t, hits, len(responses), "too many API calls")
+		raw := responses[hits]
+		hits++
+		return io.NopCloser(strings.NewReader(raw)), nil
+	}
+
+	ios, _, stdout, stderr := iostreams.Test()
+
+	err := NewLogRenderer().FollowReader(fetcher, stdout, ios, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 1, strings.Count(stdout.String(), "first message"))
+	assert.Equal(t, 1, strings.Count(stdout.String(), "all done"))
+	require.Empty(t, stderr, "expected no stderr output")
+}
+
+func TestRelativeFilePath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{
+			name: "hosted runner path",
+			path: "/home/runner/work/OWNER/REPO/path/to/file.go",
+			want: "path/to/file.go",
+		},
+		{
+			name: "hosted runner path at the checkout root",
+			path: "/home/runner/work/OWNER/REPO",
+			want: "repository",
+		},
+		{
+			name: "self-hosted runner path",
+			path: "/opt/actions-runner/_work/OWNER/REPO/src/main.go",
+			want: "src/main.go",
+		},
+		{
+			name: "self-hosted runner path at the checkout root",
+			path: "/opt/actions-runner/_work/OWNER/REPO",
+			want: "repository",
+		},
+		{
+			name: "custom runner root with a work segment",
+			path: "/srv/ci/work/OWNER/REPO/docs/readme.md",
+			want: "docs/readme.md",
+		},
+		{
+			name: "path without a workspace root is unchanged",
+			path: "/tmp/scratch/file.txt",
+			want: "/tmp/scratch/file.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relativeFilePath(tt.path))
+		})
+	}
+}
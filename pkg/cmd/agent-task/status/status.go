@@ -0,0 +1,146 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cli/cli/v2/internal/ghrepo"
+	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
+	"github.com/cli/cli/v2/pkg/cmd/agent-task/shared"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/spf13/cobra"
+)
+
+const defaultLimit = 100
+
+// stateDisplayOrder fixes the order in which state tallies are printed:
+// active states first, then terminal outcomes. States not listed here are
+// appended alphabetically.
+var stateDisplayOrder = []string{
+	capi.SessionStateInProgress,
+	capi.SessionStateQueued,
+	capi.SessionStateWaitingForUser,
+	capi.SessionStateIdle,
+	capi.SessionStateCompleted,
+	capi.SessionStateFailed,
+	capi.SessionStateTimedOut,
+	capi.SessionStateCancelled,
+}
+
+// StatusOptions are the options for the status command
+type StatusOptions struct {
+	IO         *iostreams.IOStreams
+	Limit      int
+	Repo       string
+	JSON       bool
+	CapiClient func() (capi.CapiClient, error)
+}
+
+// NewCmdStatus creates the status command
+func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Command {
+	opts := &StatusOptions{
+		IO:         f.IOStreams,
+		CapiClient: shared.CapiClientFunc(f),
+		Limit:      defaultLimit,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show a summary of agent tasks by state (preview)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Limit < 1 {
+				return cmdutil.FlagErrorf("invalid limit: %v", opts.Limit)
+			}
+			if opts.Repo != "" {
+				if _, err := ghrepo.FromFullName(opts.Repo); err != nil {
+					return cmdutil.FlagErrorf("invalid value for --repo: %v", err)
+				}
+			}
+			if runF != nil {
+				return runF(opts)
+			}
+			return statusRun(opts)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", defaultLimit, fmt.Sprintf("Maximum number of agent tasks to tally (default %d)", defaultLimit))
+	cmd.Flags().StringVarP(&opts.Repo, "repo", "R", "", "Show status for a repository in `OWNER/REPO` format")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output the state counts as JSON")
+
+	return cmd
+}
+
+func statusRun(opts *StatusOptions) error {
+	capiClient, err := opts.CapiClient()
+	if err != nil {
+		return err
+	}
+
+	opts.IO.StartProgressIndicatorWithLabel("Fetching agent tasks...")
+	defer opts.IO.StopProgressIndicator()
+
+	ctx := context.Background()
+	var sessions []*capi.Session
+	if opts.Repo != "" {
+		repo, err := ghrepo.FromFullName(opts.Repo)
+		if err != nil {
+			return err
+		}
+		sessions, err = capiClient.ListSessionsForRepo(ctx, repo.RepoOwner(), repo.RepoName(), opts.Limit)
+		if err != nil {
+			return err
+		}
+	} else {
+		sessions, err = capiClient.ListLatestSessionsForViewer(ctx, opts.Limit)
+		if err != nil {
+			return err
+		}
+	}
+
+	opts.IO.StopProgressIndicator()
+
+	counts := make(map[string]int, len(stateDisplayOrder))
+	for _, s := range sessions {
+		state := s.State
+		// The API has used both spellings; tally them as one.
+		if state == capi.SessionStateCanceled {
+			state = capi.SessionStateCancelled
+		}
+		counts[state]++
+	}
+
+	if opts.JSON {
+		b, err := json.Marshal(counts)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(opts.IO.Out, string(b))
+		return nil
+	}
+
+	if len(sessions) == 0 {
+		return cmdutil.NewNoResultsError("no agent tasks found")
+	}
+
+	cs := opts.IO.ColorScheme()
+	parts := make([]string, 0, len(counts))
+	for _, state := range stateDisplayOrder {
+		if n := counts[state]; n > 0 {
+			stateColor := shared.ColorFuncForSessionState(capi.Session{State: state}, cs)
+			parts = append(parts, stateColor(fmt.Sprintf("%d %s", n, state)))
+			delete(counts, state)
+		}
+	}
+	for _, state := range slices.Sorted(maps.Keys(counts)) {
+		parts = append(parts, cs.Muted(fmt.Sprintf("%d %s", counts[state], state)))
+	}
+
+	fmt.Fprintln(opts.IO.Out, strings.Join(parts, ", "))
+	return nil
+}
@@ -0,0 +1,210 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/cli/cli/v2/pkg/cmd/agent-task/capi"
+	"github.com/cli/cli/v2/pkg/cmdutil"
+	"github.com/cli/cli/v2/pkg/iostreams"
+	"github.com/google/shlex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCmdStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     string
+		wantOpts StatusOptions
+		wantErr  string
+	}{
+		{
+			name: "no arguments",
+			wantOpts: StatusOptions{
+				Limit: defaultLimit,
+			},
+		},
+		{
+			name: "custom limit",
+			args: "--limit 15",
+			wantOpts: StatusOptions{
+				Limit: 15,
+			},
+		},
+		{
+			name:    "invalid limit",
+			args:    "--limit 0",
+			wantErr: "invalid limit: 0",
+		},
+		{
+			name: "repo flag",
+			args: "--repo OWNER/REPO",
+			wantOpts: StatusOptions{
+				Limit: defaultLimit,
+				Repo:  "OWNER/REPO",
+			},
+		},
+		{
+			name:    "invalid repo",
+			args:    "--repo not-a-repo",
+			wantErr: `invalid value for --repo: expected the "[HOST/]OWNER/REPO" format, got "not-a-repo"`,
+		},
+		{
+			name: "json flag",
+			args: "--json",
+			wantOpts: StatusOptions{
+				Limit: defaultLimit,
+				JSON:  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, _, _ := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: ios,
+			}
+
+			var gotOpts *StatusOptions
+			cmd := NewCmdStatus(f, func(opts *StatusOptions) error { gotOpts = opts; return nil })
+
+			argv, err := shlex.Split(tt.args)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOpts.Limit, gotOpts.Limit)
+			assert.Equal(t, tt.wantOpts.Repo, gotOpts.Repo)
+			assert.Equal(t, tt.wantOpts.JSON, gotOpts.JSON)
+		})
+	}
+}
+
+func Test_statusRun(t *testing.T) {
+	mixedSessions := []*capi.Session{
+		{ID: "s1", State: "in_progress"},
+		{ID: "s2", State: "in_progress"},
+		{ID: "s3", State: "queued"},
+		{ID: "s4", State: "completed"},
+		{ID: "s5", State: "failed"},
+		// Both spellings must land in the same bucket.
+		{ID: "s6", State: "canceled"},
+		{ID: "s7", State: "cancelled"},
+	}
+
+	tests := []struct {
+		name      string
+		opts      StatusOptions
+		capiStubs func(t *testing.T, m *capi.CapiClientMock)
+		wantOut   string
+		wantErr   string
+	}{
+		{
+			name: "no sessions",
+			opts: StatusOptions{Limit: defaultLimit},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(_ context.Context, limit int) ([]*capi.Session, error) {
+					return nil, nil
+				}
+			},
+			wantErr: "no agent tasks found",
+		},
+		{
+			name: "no sessions with json",
+			opts: StatusOptions{Limit: defaultLimit, JSON: true},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(_ context.Context, limit int) ([]*capi.Session, error) {
+					return nil, nil
+				}
+			},
+			wantOut: "{}\n",
+		},
+		{
+			name: "mixed states",
+			opts: StatusOptions{Limit: defaultLimit},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(_ context.Context, limit int) ([]*capi.Session, error) {
+					assert.Equal(t, defaultLimit, limit)
+					return mixedSessions, nil
+				}
+			},
+			wantOut: "2 in_progress, 1 queued, 1 completed, 1 failed, 2 cancelled\n",
+		},
+		{
+			name: "mixed states with json",
+			opts: StatusOptions{Limit: defaultLimit, JSON: true},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(_ context.Context, limit int) ([]*capi.Session, error) {
+					return mixedSessions, nil
+				}
+			},
+			wantOut: `{"cancelled":2,"completed":1,"failed":1,"in_progress":2,"queued":1}` + "\n",
+		},
+		{
+			name: "repo scoped",
+			opts: StatusOptions{Limit: defaultLimit, Repo: "OWNER/REPO"},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListSessionsForRepoFunc = func(_ context.Context, owner, repo string, limit int) ([]*capi.Session, error) {
+					assert.Equal(t, "OWNER", owner)
+					assert.Equal(t, "REPO", repo)
+					return []*capi.Session{{ID: "s1", State: "completed"}}, nil
+				}
+			},
+			wantOut: "1 completed\n",
+		},
+		{
+			name: "unknown state is still tallied",
+			opts: StatusOptions{Limit: defaultLimit},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListLatestSessionsForViewerFunc = func(_ context.Context, limit int) ([]*capi.Session, error) {
+					return []*capi.Session{
+						{ID: "s1", State: "completed"},
+						{ID: "s2", State: "hibernating"},
+					}, nil
+				}
+			},
+			wantOut: "1 completed, 1 hibernating\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capiClientMock := &capi.CapiClientMock{}
+			if tt.capiStubs != nil {
+				tt.capiStubs(t, capiClientMock)
+			}
+
+			ios, _, stdout, stderr := iostreams.Test()
+
+			opts := tt.opts
+			opts.IO = ios
+			opts.CapiClient = func() (capi.CapiClient, error) {
+				return capiClientMock, nil
+			}
+
+			err := statusRun(&opts)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, stdout.String())
+			assert.Empty(t, stderr.String())
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -29,6 +31,14 @@ const (
 	defaultLogPollInterval = 5 * time.Second
 )
 
+// Values accepted by --format. The markdown variant emits plain
+// GitHub-flavored markdown with no terminal styling, suitable for pasting
+// into a pull request or chat.
+const (
+	formatText     = "text"
+	formatMarkdown = "markdown"
+)
+
 type ViewOptions struct {
 	IO         *iostreams.IOStreams
 	BaseRepo   func() (ghrepo.Interface, error)
@@ -38,19 +48,31 @@ type ViewOptions struct {
 	Prompter   prompter.Prompter
 	Browser    browser.Browser
 
-	LogRenderer func() shared.LogRenderer
+	LogRenderer func(opts ...shared.LogRendererOption) shared.LogRenderer
 	Sleep       func(d time.Duration)
 
-	SelectorArg string
-	PRNumber    int
-	SessionID   string
-	Web         bool
-	Log         bool
-	Follow      bool
+	Exporter cmdutil.Exporter
+
+	SelectorArg   string
+	PRNumber      int
+	SessionID     string
+	Web           bool
+	Log           bool
+	Comments      bool
+	Follow        bool
+	ExitStatus    bool
+	HideReasoning bool
+	NoPager       bool
+	NoIcons       bool
+	Since         string
+	SinceTime     time.Time
+	Output        string
+	Format        string
+	Interval      time.Duration
 }
 
-func defaultLogRenderer() shared.LogRenderer {
-	return shared.NewLogRenderer()
+func defaultLogRenderer(opts ...shared.LogRendererOption) shared.LogRenderer {
+	return shared.NewLogRenderer(opts...)
 }
 
 func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Command {
@@ -69,6 +91,16 @@ func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Comman
 		Short: "View an agent task session (preview)",
 		Long: heredoc.Doc(`
 			View an agent task session.
+
+			The argument is interpreted in this order: a session ID (UUID), a
+			session URL, a pull request reference (e.g. "OWNER/REPO#123"), and
+			finally anything a pull request selector accepts — a PR number, a PR
+			URL, or a PR branch name. Note that a purely numeric argument is
+			always treated as a pull request number, so select by PR number or
+			URL instead when a branch name happens to look like a number.
+
+			With --exit-status, the command exits with 1 when the viewed session
+			failed or timed out.
 		`),
 		Example: heredoc.Doc(`
 			# View an agent task by session ID
@@ -83,6 +115,9 @@ func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Comman
 			# View an agent task by pull request reference
 			$ gh agent-task view OWNER/REPO#12345
 
+			# View an agent task by pull request branch name
+			$ gh agent-task view my-feature-branch
+
 			# View a pull request agents tasks in the browser
 			$ gh agent-task view 12345 --web
 		`),
@@ -108,6 +143,53 @@ func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Comman
 				return cmdutil.FlagErrorf("--log is required when providing --follow")
 			}
 
+			if opts.Comments && opts.Log {
+				return cmdutil.FlagErrorf("--comments cannot be used with --log")
+			}
+
+			if opts.Output != "" && !opts.Log {
+				return cmdutil.FlagErrorf("--log is required when providing --output")
+			}
+
+			if opts.HideReasoning && !opts.Log {
+				return cmdutil.FlagErrorf("--log is required when providing --hide-reasoning")
+			}
+
+			if opts.NoPager && !opts.Log {
+				return cmdutil.FlagErrorf("--log is required when providing --no-pager")
+			}
+
+			// The format flag shapes the session summary, which log output
+			// bypasses entirely.
+			if opts.Format == formatMarkdown && opts.Log {
+				return cmdutil.FlagErrorf("--format cannot be used with --log")
+			}
+
+			if cmd.Flags().Changed("interval") {
+				if !opts.Follow {
+					return cmdutil.FlagErrorf("--follow is required when providing --interval")
+				}
+				// Bound the poll cadence: anything under a second hammers the
+				// API, and very long intervals are almost certainly a typo.
+				if opts.Interval < time.Second {
+					return cmdutil.FlagErrorf("invalid value for --interval: must be at least 1s")
+				}
+				if opts.Interval > 10*time.Minute {
+					return cmdutil.FlagErrorf("invalid value for --interval: must be at most 10m")
+				}
+			}
+
+			if opts.Since != "" {
+				if !opts.Log {
+					return cmdutil.FlagErrorf("--log is required when providing --since")
+				}
+				sinceTime, err := parseSinceTime(opts.Since, time.Now())
+				if err != nil {
+					return cmdutil.FlagErrorf("invalid value for --since: %v", err)
+				}
+				opts.SinceTime = sinceTime
+			}
+
 			if opts.Finder == nil {
 				opts.Finder = prShared.NewFinder(f)
 			}
@@ -123,12 +205,46 @@ func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Comman
 
 	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open agent task in the browser")
 	cmd.Flags().BoolVar(&opts.Log, "log", false, "Show agent session logs")
+	cmd.Flags().BoolVarP(&opts.Comments, "comments", "c", false, "Show the comments on the session's pull request")
 	cmd.Flags().BoolVar(&opts.Follow, "follow", false, "Follow agent session logs")
+	cmd.Flags().BoolVar(&opts.ExitStatus, "exit-status", false, "Exit with non-zero status if the session failed or timed out")
+	cmd.Flags().BoolVar(&opts.HideReasoning, "hide-reasoning", false, "Hide the agent's reasoning and thought entries in log output")
+	cmd.Flags().BoolVar(&opts.NoPager, "no-pager", false, "Write log output directly to the terminal instead of a pager")
+	cmd.Flags().BoolVar(&opts.NoIcons, "no-icons", false, "Use plain ASCII characters instead of Unicode icons for session states")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only show log entries newer than a `duration` (e.g. \"1h\") or timestamp")
+	cmd.Flags().StringVarP(&opts.Output, "output", "O", "", "The `file` to write rendered logs to (use \"-\" to write to standard output)")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", defaultLogPollInterval, "Time to wait between log polls when following, as a `duration`")
+	cmdutil.StringEnumFlag(cmd, &opts.Format, "format", "", formatText, []string{formatText, formatMarkdown}, "Output format for the session summary")
+	cmdutil.AddJSONFlags(cmd, &opts.Exporter, capi.SessionFields)
 
 	return cmd
 }
 
 func viewRun(opts *ViewOptions) error {
+	// A reference selector (e.g. "OWNER/REPO#123") resolves against the base
+	// repo's host, so an unsupported host can be rejected up front, before
+	// any client is constructed or network request issued.
+	var refRepo ghrepo.Interface
+	var refNumber int
+	if opts.SessionID == "" && opts.SelectorArg != "" {
+		if repo, num, err := prShared.ParseFullReference(opts.SelectorArg); err == nil {
+			// Since the selector was a reference (i.e. without hostname data), we need to
+			// check the base repo to get the hostname.
+			baseRepo, err := opts.BaseRepo()
+			if err != nil {
+				return err
+			}
+
+			hostname := baseRepo.RepoHost()
+			if hostname != ghinstance.Default() {
+				return fmt.Errorf("agent tasks are not supported on this host: %s", hostname)
+			}
+
+			refRepo = repo
+			refNumber = num
+		}
+	}
+
 	capiClient, err := opts.CapiClient()
 	if err != nil {
 		return err
@@ -161,7 +277,10 @@ func viewRun(opts *ViewOptions) error {
 			} else {
 				// Currently the web Copilot Agents home GUI does not support focusing
 				// on a given session, so we should just navigate to the home page.
-				webURL = capi.AgentsHomeURL
+				webURL = capi.AgentsHomeURL()
+				if opts.IO.IsStdoutTTY() {
+					fmt.Fprintln(opts.IO.ErrOut, "This session has no pull request yet; opening the Copilot agents home instead.")
+				}
 			}
 
 			if opts.IO.IsStdoutTTY() {
@@ -175,27 +294,12 @@ func viewRun(opts *ViewOptions) error {
 		var prID int64
 		var prURL string
 
-		if opts.SelectorArg != "" {
-			// Finder does not support the PR/issue reference format (e.g. owner/repo#123)
-			// so we need to check if the selector arg is a reference and fetch the PR
-			// directly.
-			if repo, num, err := prShared.ParseFullReference(opts.SelectorArg); err == nil {
-				// Since the selector was a reference (i.e. without hostname data), we need to
-				// check the base repo to get the hostname.
-				baseRepo, err := opts.BaseRepo()
-				if err != nil {
-					return err
-				}
-
-				hostname := baseRepo.RepoHost()
-				if hostname != ghinstance.Default() {
-					return fmt.Errorf("agent tasks are not supported on this host: %s", hostname)
-				}
-
-				prID, prURL, err = capiClient.GetPullRequestDatabaseID(ctx, hostname, repo.RepoOwner(), repo.RepoName(), num)
-				if err != nil {
-					return fmt.Errorf("failed to fetch pull request: %w", err)
-				}
+		// Finder does not support the PR/issue reference format (e.g. owner/repo#123)
+		// so when the selector arg was a reference we fetch the PR directly.
+		if refRepo != nil {
+			prID, prURL, err = capiClient.GetPullRequestDatabaseID(ctx, ghinstance.Default(), refRepo.RepoOwner(), refRepo.RepoName(), refNumber)
+			if err != nil {
+				return fmt.Errorf("failed to fetch pull request: %w", err)
 			}
 		}
 
@@ -255,10 +359,14 @@ func viewRun(opts *ViewOptions) error {
 		if len(sessions) > 1 {
 			now := time.Now()
 			options := make([]string, 0, len(sessions))
+			sessionSymbol := shared.SessionSymbol
+			if opts.NoIcons {
+				sessionSymbol = shared.SessionSymbolASCII
+			}
 			for _, session := range sessions {
 				options = append(options, fmt.Sprintf(
 					"%s %s • updated %s",
-					shared.SessionSymbol(cs, session.State),
+					sessionSymbol(cs, session.State),
 					session.Name,
 					text.FuzzyAgo(now, session.LastUpdatedAt),
 				))
@@ -285,15 +393,43 @@ func viewRun(opts *ViewOptions) error {
 		opts.IO.StopProgressIndicator()
 	}
 
-	if opts.Log {
-		return printLogs(opts, capiClient, session.ID)
+	if opts.Exporter != nil {
+		if err := opts.Exporter.Write(opts.IO, session); err != nil {
+			return err
+		}
+	} else if opts.Log {
+		if err := printLogs(opts, capiClient, session.ID); err != nil {
+			return err
+		}
+	} else {
+		// Resolve the session's numeric agent ID into a name. Best effort:
+		// the session is still displayable when the agents can't be listed.
+		var agentName string
+		if session.AgentID != 0 {
+			if names, err := capi.AgentNamesByID(ctx, capiClient); err == nil {
+				agentName = names[session.AgentID]
+			}
+		}
+		if opts.Format == formatMarkdown {
+			printSessionMarkdown(opts, session, agentName)
+		} else {
+			printSession(opts, session, agentName)
+		}
+
+		if opts.Comments {
+			if err := printPullRequestComments(opts, session); err != nil {
+				return err
+			}
+		}
 	}
 
-	printSession(opts, session)
+	if opts.ExitStatus && session.IsTerminal() && shared.SessionStateIndicatesFailure(session.State) {
+		return cmdutil.SilentError
+	}
 	return nil
 }
 
-func printSession(opts *ViewOptions, session *capi.Session) {
+func printSession(opts *ViewOptions, session *capi.Session, agentName string) {
 	cs := opts.IO.ColorScheme()
 
 	fmt.Fprintf(opts.IO.Out, "%s • %s\n",
@@ -308,6 +444,10 @@ func printSession(opts *ViewOptions, session *capi.Session) {
 		fmt.Fprintf(opts.IO.Out, "Started %s\n", text.FuzzyAgo(time.Now(), session.CreatedAt))
 	}
 
+	if agentName != "" {
+		fmt.Fprintln(opts.IO.Out, cs.Mutedf("Agent: %s", agentName))
+	}
+
 	usedPremiumRequests := strings.TrimSuffix(fmt.Sprintf("%.1f", session.PremiumRequests), ".0")
 	usedPremiumRequestsNote := fmt.Sprintf("Used %s premium request(s)", usedPremiumRequests)
 
@@ -318,6 +458,11 @@ func printSession(opts *ViewOptions, session *capi.Session) {
 
 	fmt.Fprintf(opts.IO.Out, "%s%s\n", cs.Muted(usedPremiumRequestsNote), cs.Muted(durationNote))
 
+	// Useful when debugging how a session was triggered (e.g. webhooks).
+	if session.EventType != "" {
+		fmt.Fprintln(opts.IO.Out, cs.Mutedf("Triggered by %s event", session.EventType))
+	}
+
 	// Note that when the session is just created, a PR is not yet available for it.
 	if session.PullRequest != nil {
 		fmt.Fprintf(opts.IO.Out, "\n%s%s • %s\n",
@@ -325,6 +470,13 @@ func printSession(opts *ViewOptions, session *capi.Session) {
 			cs.ColorFromString(prShared.ColorForPRState(*session.PullRequest))(fmt.Sprintf("#%d", session.PullRequest.Number)),
 			cs.Bold(session.PullRequest.Title),
 		)
+	} else if session.Issue != nil {
+		fmt.Fprintf(opts.IO.Out, "\nIssue %s • %s\n",
+			fmt.Sprintf("#%d", session.Issue.Number),
+			cs.Bold(session.Issue.Title),
+		)
+	} else if session.PullRequestInaccessible {
+		fmt.Fprintln(opts.IO.Out, cs.Muted("\nThis session's pull request is no longer accessible; it may have been deleted."))
 	}
 
 	if session.Error != nil {
@@ -348,6 +500,13 @@ func printSession(opts *ViewOptions, session *capi.Session) {
 		}
 	}
 
+	// The agent's final summary only makes sense once the session has reached
+	// a terminal state; in-flight sessions may carry a stale partial result.
+	if session.Result != "" && session.IsTerminal() {
+		fmt.Fprintln(opts.IO.Out, cs.Muted("\nResult:"))
+		fmt.Fprintln(opts.IO.Out, session.Result)
+	}
+
 	if !opts.Log {
 		fmt.Fprint(opts.IO.Out, cs.Mutedf("\nFor detailed session logs, try:\ngh agent-task view '%s' --log\n", session.ID))
 	} else if !opts.Follow {
@@ -360,32 +519,149 @@ func printSession(opts *ViewOptions, session *capi.Session) {
 	}
 }
 
+// printSessionMarkdown renders the session summary as plain GitHub-flavored
+// markdown with no terminal styling, for redirecting into a pull request
+// comment or chat message.
+func printSessionMarkdown(opts *ViewOptions, session *capi.Session, agentName string) {
+	out := opts.IO.Out
+
+	fmt.Fprintf(out, "## %s\n\n", session.Name)
+	fmt.Fprintf(out, "- State: %s\n", shared.SessionStateString(session.State))
+	if session.User != nil {
+		fmt.Fprintf(out, "- Started on behalf of: @%s\n", session.User.Login)
+	}
+	fmt.Fprintf(out, "- Created: %s\n", session.CreatedAt.Format(time.RFC3339))
+	if session.CompletedAt.After(session.CreatedAt) {
+		fmt.Fprintf(out, "- Duration: %s\n", session.CompletedAt.Sub(session.CreatedAt).Round(time.Second))
+	}
+	if agentName != "" {
+		fmt.Fprintf(out, "- Agent: %s\n", agentName)
+	}
+	if session.PullRequest != nil {
+		name := fmt.Sprintf("#%d", session.PullRequest.Number)
+		if session.PullRequest.Repository != nil {
+			name = session.PullRequest.Repository.NameWithOwner + name
+		}
+		fmt.Fprintf(out, "- Pull request: [%s](%s)\n", name, session.PullRequest.URL)
+		fmt.Fprintf(out, "- Session: %s/agent-sessions/%s\n", session.PullRequest.URL, url.PathEscape(session.ID))
+	} else if session.Issue != nil {
+		fmt.Fprintf(out, "- Issue: [#%d](%s)\n", session.Issue.Number, session.Issue.URL)
+	}
+
+	if session.Result != "" && session.IsTerminal() {
+		fmt.Fprintf(out, "\n%s\n", session.Result)
+	}
+}
+
+// printPullRequestComments renders the conversation on the session's pull
+// request below the session summary, mirroring `gh pr view --comments`. A
+// session without a pull request has no conversation yet, which is not an
+// error.
+func printPullRequestComments(opts *ViewOptions, session *capi.Session) error {
+	if session.PullRequest == nil {
+		fmt.Fprintln(opts.IO.Out, "\nNo pull request for this session yet, so there are no comments to show.")
+		return nil
+	}
+
+	pr, _, err := opts.Finder.Find(prShared.FindOptions{
+		Selector: session.PullRequest.URL,
+		Fields:   []string{"number", "url", "comments", "reviews"},
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.IO.IsStdoutTTY() {
+		comments, err := prShared.CommentList(opts.IO, pr.Comments, pr.DisplayableReviews(), false)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(opts.IO.Out)
+		fmt.Fprint(opts.IO.Out, comments)
+		return nil
+	}
+
+	fmt.Fprint(opts.IO.Out, prShared.RawCommentList(pr.Comments, pr.DisplayableReviews()))
+	return nil
+}
+
+// parseSinceTime converts the --since value into a cutoff time. The value is
+// either a duration (relative to now) or an RFC3339/date-only timestamp.
+func parseSinceTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected a duration or timestamp, got %q", s)
+}
+
 func printLogs(opts *ViewOptions, capiClient capi.CapiClient, sessionID string) error {
 	ctx := context.Background()
 
-	renderer := opts.LogRenderer()
+	var rendererOpts []shared.LogRendererOption
+	if opts.HideReasoning {
+		rendererOpts = append(rendererOpts, shared.WithHiddenReasoning())
+	}
+	renderer := opts.LogRenderer(rendererOpts...)
 
-	if err := opts.IO.StartPager(); err == nil {
-		defer opts.IO.StopPager()
-	} else {
-		fmt.Fprintf(opts.IO.ErrOut, "error starting pager: %v\n", err)
+	var filter shared.LogEntryFilter
+	if !opts.SinceTime.IsZero() {
+		filter = shared.SinceLogEntryFilter(opts.SinceTime)
+	}
+
+	// "-" preserves the default behavior of writing to standard output.
+	out := io.Writer(opts.IO.Out)
+	toFile := opts.Output != "" && opts.Output != "-"
+	if toFile {
+		f, err := os.Create(opts.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	// Only page output going to the terminal; a pager has nothing to show
+	// when the logs are written to a file. Following is also never paged:
+	// a pager waits for its input to end, which a followed stream doesn't.
+	if !toFile && !opts.Follow && !opts.NoPager {
+		if err := opts.IO.StartPager(); err == nil {
+			defer opts.IO.StopPager()
+		} else {
+			fmt.Fprintf(opts.IO.ErrOut, "error starting pager: %v\n", err)
+		}
 	}
 
 	if opts.Follow {
+		// Options constructed directly (e.g. in tests) may leave the poll
+		// interval unset; fall back to the flag's default.
+		interval := opts.Interval
+		if interval == 0 {
+			interval = defaultLogPollInterval
+		}
+
 		var called bool
-		fetcher := func() ([]byte, error) {
+		fetcher := func() (io.ReadCloser, error) {
 			if called {
-				opts.Sleep(defaultLogPollInterval)
+				// The indicator is a no-op for non-tty output, so piped
+				// output stays clean.
+				opts.IO.StartProgressIndicatorWithLabel("Waiting for new output...")
+				opts.Sleep(interval)
 			}
 			called = true
-			raw, err := capiClient.GetSessionLogs(ctx, sessionID)
+			body, err := capiClient.GetSessionLogsReader(ctx, sessionID)
+			opts.IO.StopProgressIndicator()
 			if err != nil {
 				return nil, err
 			}
-			return raw, nil
+			return body, nil
 		}
 
-		return renderer.Follow(fetcher, opts.IO.Out, opts.IO)
+		return renderer.FollowReader(fetcher, out, opts.IO, filter)
 	}
 
 	raw, err := capiClient.GetSessionLogs(ctx, sessionID)
@@ -393,6 +669,14 @@ func printLogs(opts *ViewOptions, capiClient capi.CapiClient, sessionID string)
 		return fmt.Errorf("failed to fetch session logs: %w", err)
 	}
 
-	_, err = renderer.Render(raw, opts.IO.Out, opts.IO)
+	// A freshly queued session has no logs yet; explain that instead of
+	// rendering nothing. Follow mode instead keeps polling silently until
+	// content arrives.
+	if strings.TrimSpace(string(raw)) == "" {
+		fmt.Fprintln(out, "No logs yet; the agent may still be starting. Try --follow.")
+		return nil
+	}
+
+	_, err = renderer.Render(raw, out, opts.IO, filter)
 	return err
 }
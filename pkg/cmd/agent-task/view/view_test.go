@@ -5,6 +5,8 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -104,6 +106,128 @@ func TestNewCmdList(t *testing.T) {
 			args:    "some-arg --follow",
 			wantErr: "--log is required when providing --follow",
 		},
+		{
+			name: "with --log and --output",
+			tty:  true,
+			args: "some-arg --log --output logs.txt",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				Log:         true,
+				Output:      "logs.txt",
+			},
+		},
+		{
+			name:    "--output requires --log",
+			tty:     true,
+			args:    "some-arg --output logs.txt",
+			wantErr: "--log is required when providing --output",
+		},
+		{
+			name: "with --log and --hide-reasoning",
+			tty:  true,
+			args: "some-arg --log --hide-reasoning",
+			wantOpts: ViewOptions{
+				SelectorArg:   "some-arg",
+				Log:           true,
+				HideReasoning: true,
+			},
+		},
+		{
+			name:    "--hide-reasoning requires --log",
+			tty:     true,
+			args:    "some-arg --hide-reasoning",
+			wantErr: "--log is required when providing --hide-reasoning",
+		},
+		{
+			name: "with --no-icons",
+			tty:  true,
+			args: "some-arg --no-icons",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				NoIcons:     true,
+			},
+		},
+		{
+			name: "with --log and --no-pager",
+			tty:  true,
+			args: "some-arg --log --no-pager",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				Log:         true,
+				NoPager:     true,
+			},
+		},
+		{
+			name:    "--no-pager requires --log",
+			tty:     true,
+			args:    "some-arg --no-pager",
+			wantErr: "--log is required when providing --no-pager",
+		},
+		{
+			name: "with --log --follow and --interval",
+			tty:  true,
+			args: "some-arg --log --follow --interval 10s",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				Log:         true,
+				Follow:      true,
+				Interval:    10 * time.Second,
+			},
+		},
+		{
+			name:    "--interval requires --follow",
+			tty:     true,
+			args:    "some-arg --log --interval 10s",
+			wantErr: "--follow is required when providing --interval",
+		},
+		{
+			name:    "--interval below one second",
+			tty:     true,
+			args:    "some-arg --log --follow --interval 100ms",
+			wantErr: "invalid value for --interval: must be at least 1s",
+		},
+		{
+			name:    "--interval above ten minutes",
+			tty:     true,
+			args:    "some-arg --log --follow --interval 1h",
+			wantErr: "invalid value for --interval: must be at most 10m",
+		},
+		{
+			name: "with --comments",
+			tty:  true,
+			args: "some-arg --comments",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				Comments:    true,
+			},
+		},
+		{
+			name:    "--comments cannot be used with --log",
+			tty:     true,
+			args:    "some-arg --log --comments",
+			wantErr: "--comments cannot be used with --log",
+		},
+		{
+			name: "with --format markdown",
+			tty:  true,
+			args: "some-arg --format markdown",
+			wantOpts: ViewOptions{
+				SelectorArg: "some-arg",
+				Format:      "markdown",
+			},
+		},
+		{
+			name:    "--format cannot be used with --log",
+			tty:     true,
+			args:    "some-arg --log --format markdown",
+			wantErr: "--format cannot be used with --log",
+		},
+		{
+			name:    "invalid --format",
+			tty:     true,
+			args:    "some-arg --format html",
+			wantErr: `invalid argument "html" for "--format" flag: valid values are {text|markdown}`,
+		},
 		{
 			name: "web mode",
 			tty:  true,
@@ -147,6 +271,17 @@ func TestNewCmdList(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, tt.wantOpts.SelectorArg, gotOpts.SelectorArg)
 			assert.Equal(t, tt.wantOpts.SessionID, gotOpts.SessionID)
+			assert.Equal(t, tt.wantOpts.Output, gotOpts.Output)
+			assert.Equal(t, tt.wantOpts.Comments, gotOpts.Comments)
+			assert.Equal(t, tt.wantOpts.HideReasoning, gotOpts.HideReasoning)
+			assert.Equal(t, tt.wantOpts.NoPager, gotOpts.NoPager)
+			assert.Equal(t, tt.wantOpts.NoIcons, gotOpts.NoIcons)
+			if tt.wantOpts.Format != "" {
+				assert.Equal(t, tt.wantOpts.Format, gotOpts.Format)
+			}
+			if tt.wantOpts.Interval != 0 {
+				assert.Equal(t, tt.wantOpts.Interval, gotOpts.Interval)
+			}
 
 			if tt.wantBaseRepo != nil {
 				baseRepo, err := gotOpts.BaseRepo()
@@ -164,6 +299,7 @@ func Test_viewRun(t *testing.T) {
 	tests := []struct {
 		name             string
 		tty              bool
+		pager            string
 		opts             ViewOptions
 		promptStubs      func(*testing.T, *prompter.MockPrompter)
 		capiStubs        func(*testing.T, *capi.CapiClientMock)
@@ -476,40 +612,399 @@ func Test_viewRun(t *testing.T) {
 				}
 			},
 			wantOut: heredoc.Doc(`
-				Failed • session one
+				Failed • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s)
+
+				OWNER/REPO#101 • fix something
+
+				X blah blah
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+
+				View this session on GitHub:
+				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
+			`),
+		},
+		{
+			name: "with session id, success, session has error with workflow id (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "failed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						WorkflowRunID:   9999,
+						Error: &capi.SessionError{
+							Message: "blah blah",
+						},
+						PullRequest: &api.PullRequest{
+							Title:  "fix something",
+							Number: 101,
+							URL:    "https://github.com/OWNER/REPO/pull/101",
+							Repository: &api.PRRepository{
+								NameWithOwner: "OWNER/REPO",
+							},
+						},
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Failed • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s)
+
+				OWNER/REPO#101 • fix something
+
+				X blah blah
+				https://github.com/OWNER/REPO/actions/runs/9999
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+
+				View this session on GitHub:
+				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
+			`),
+		},
+		{
+			name: "with session id, failed session, exit status (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				ExitStatus:  true,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "failed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Failed • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s)
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+			wantErr: cmdutil.SilentError,
+		},
+		{
+			name: "with session id, completed session, exit status (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				ExitStatus:  true,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "completed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						CompletedAt:     sampleCompletedAt,
+						PremiumRequests: 1.5,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Ready for review • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s) • Duration 5m0s
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+		},
+		{
+			name: "with session id, completed session with result (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "completed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						CompletedAt:     sampleCompletedAt,
+						PremiumRequests: 1.5,
+						Result:          "All checks passing; opened a pull request with the fix.",
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Ready for review • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s) • Duration 5m0s
+
+				Result:
+				All checks passing; opened a pull request with the fix.
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+		},
+		{
+			name: "with session id, agent ID resolved to a name (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "in_progress",
+						Name:            "session one",
+						AgentID:         2,
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+				m.ListAgentsFunc = func(_ context.Context) ([]*capi.Agent, error) {
+					return []*capi.Agent{
+						{ID: 2, Name: "Copilot coding agent"},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				In progress • session one
+				Started on behalf of octocat about 6 hours ago
+				Agent: Copilot coding agent
+				Used 1.5 premium request(s)
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+		},
+		{
+			name: "with session id, unknown agent ID is omitted (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "in_progress",
+						Name:            "session one",
+						AgentID:         99,
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+				m.ListAgentsFunc = func(_ context.Context) ([]*capi.Agent, error) {
+					return []*capi.Agent{
+						{ID: 2, Name: "Copilot coding agent"},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				In progress • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s)
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+		},
+		{
+			name: "with session id, in-progress session result is not shown (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "in_progress",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						Result:          "partial summary",
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				In progress • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s)
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+			`),
+		},
+		{
+			name: "with session id and --comments (nontty)",
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				Comments:    true,
+				Finder: prShared.NewMockFinder(
+					"https://github.com/OWNER/REPO/pull/101",
+					&api.PullRequest{
+						Number: 101,
+						URL:    "https://github.com/OWNER/REPO/pull/101",
+						Comments: api.Comments{
+							Nodes: []api.Comment{
+								{
+									Author:            api.CommentAuthor{Login: "octocat"},
+									AuthorAssociation: "NONE",
+									Body:              "looks good",
+									CreatedAt:         sampleDate,
+								},
+							},
+							TotalCount: 1,
+						},
+					},
+					ghrepo.New("OWNER", "REPO"),
+				),
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "completed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						CompletedAt:     sampleCompletedAt,
+						PremiumRequests: 1.5,
+						PullRequest: &api.PullRequest{
+							Title:  "fix something",
+							Number: 101,
+							URL:    "https://github.com/OWNER/REPO/pull/101",
+							Repository: &api.PRRepository{
+								NameWithOwner: "OWNER/REPO",
+							},
+						},
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Ready for review • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s) • Duration 5m0s
+
+				OWNER/REPO#101 • fix something
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+
+				View this session on GitHub:
+				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
+				author:	octocat
+				association:	none
+				edited:	false
+				status:	none
+				--
+				looks good
+				--
+			`),
+		},
+		{
+			name: "with session id and --comments, no pull request (nontty)",
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				Comments:    true,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "in_progress",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						PremiumRequests: 1.5,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				In progress • session one
 				Started on behalf of octocat about 6 hours ago
 				Used 1.5 premium request(s)
 
-				OWNER/REPO#101 • fix something
-
-				X blah blah
-
 				For detailed session logs, try:
 				gh agent-task view 'some-session-id' --log
 
-				View this session on GitHub:
-				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
+				No pull request for this session yet, so there are no comments to show.
 			`),
 		},
 		{
-			name: "with session id, success, session has error with workflow id (tty)",
-			tty:  true,
+			name: "with session id and --format markdown (nontty)",
+			tty:  false,
 			opts: ViewOptions{
 				SelectorArg: "some-session-id",
 				SessionID:   "some-session-id",
+				Format:      "markdown",
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
 				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
-					assert.Equal(t, "some-session-id", id)
 					return &capi.Session{
-						ID:              "some-session-id",
-						State:           "failed",
-						Name:            "session one",
-						CreatedAt:       sampleDate,
-						PremiumRequests: 1.5,
-						WorkflowRunID:   9999,
-						Error: &capi.SessionError{
-							Message: "blah blah",
+						ID:          "some-session-id",
+						State:       "completed",
+						Name:        "session one",
+						CreatedAt:   sampleDate,
+						CompletedAt: sampleCompletedAt,
+						Result:      "All done.",
+						User: &api.GitHubUser{
+							Login: "octocat",
 						},
 						PullRequest: &api.PullRequest{
 							Title:  "fix something",
@@ -519,28 +1014,17 @@ func Test_viewRun(t *testing.T) {
 								NameWithOwner: "OWNER/REPO",
 							},
 						},
-						User: &api.GitHubUser{
-							Login: "octocat",
-						},
 					}, nil
 				}
 			},
-			wantOut: heredoc.Doc(`
-				Failed • session one
-				Started on behalf of octocat about 6 hours ago
-				Used 1.5 premium request(s)
-
-				OWNER/REPO#101 • fix something
-
-				X blah blah
-				https://github.com/OWNER/REPO/actions/runs/9999
-
-				For detailed session logs, try:
-				gh agent-task view 'some-session-id' --log
-
-				View this session on GitHub:
-				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
-			`),
+			wantOut: "## session one\n\n" +
+				"- State: Ready for review\n" +
+				"- Started on behalf of: @octocat\n" +
+				"- Created: " + sampleDate.Format(time.RFC3339) + "\n" +
+				"- Duration: 5m0s\n" +
+				"- Pull request: [OWNER/REPO#101](https://github.com/OWNER/REPO/pull/101)\n" +
+				"- Session: https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id\n" +
+				"\nAll done.\n",
 		},
 		{
 			name: "with session id, not found, web mode (tty)",
@@ -581,7 +1065,10 @@ func Test_viewRun(t *testing.T) {
 				}
 			},
 			wantBrowserURL: "https://github.com/copilot/agents",
-			wantStderr:     "Opening https://github.com/copilot/agents in your browser.\n",
+			wantStderr: heredoc.Doc(`
+				This session has no pull request yet; opening the Copilot agents home instead.
+				Opening https://github.com/copilot/agents in your browser.
+			`),
 		},
 		{
 			name: "with session id, with pr data, web mode (tty)",
@@ -750,6 +1237,72 @@ func Test_viewRun(t *testing.T) {
 				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
 			`),
 		},
+		{
+			name: "with pr branch, success, single session (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "my-feature-branch",
+				Finder: prShared.NewMockFinder(
+					"my-feature-branch",
+					&api.PullRequest{
+						FullDatabaseID: "999999",
+						URL:            "https://github.com/OWNER/REPO/pull/101",
+					},
+					ghrepo.New("OWNER", "REPO"),
+				),
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.ListSessionsByResourceIDFunc = func(_ context.Context, resourceType string, resourceID int64, limit int) ([]*capi.Session, error) {
+					assert.Equal(t, "pull", resourceType)
+					assert.Equal(t, int64(999999), resourceID)
+					assert.Equal(t, defaultLimit, limit)
+					return []*capi.Session{
+						{
+							ID:            "some-session-id",
+							Name:          "session one",
+							State:         "completed",
+							LastUpdatedAt: sampleCompletedAt,
+						},
+					}, nil
+				}
+
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:              "some-session-id",
+						State:           "completed",
+						Name:            "session one",
+						CreatedAt:       sampleDate,
+						CompletedAt:     sampleCompletedAt,
+						PremiumRequests: 1.5,
+						PullRequest: &api.PullRequest{
+							Title:  "fix something",
+							Number: 101,
+							URL:    "https://github.com/OWNER/REPO/pull/101",
+							Repository: &api.PRRepository{
+								NameWithOwner: "OWNER/REPO",
+							},
+						},
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				Ready for review • session one
+				Started on behalf of octocat about 6 hours ago
+				Used 1.5 premium request(s) • Duration 5m0s
+
+				OWNER/REPO#101 • fix something
+
+				For detailed session logs, try:
+				gh agent-task view 'some-session-id' --log
+
+				View this session on GitHub:
+				https://github.com/OWNER/REPO/pull/101/agent-sessions/some-session-id
+			`),
+		},
 		{
 			name: "with pr number, success, multiple sessions (tty)",
 			tty:  true,
@@ -1145,7 +1698,7 @@ func Test_viewRun(t *testing.T) {
 				}
 			},
 			logRendererStubs: func(t *testing.T, m *shared.LogRendererMock) {
-				m.RenderFunc = func(raw []byte, w io.Writer, ios *iostreams.IOStreams) (bool, error) {
+				m.RenderFunc = func(raw []byte, w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) (bool, error) {
 					w.Write([]byte("(rendered:) " + string(raw) + "\n"))
 					return false, nil
 				}
@@ -1154,6 +1707,36 @@ func Test_viewRun(t *testing.T) {
 				(rendered:) <raw-logs>
 			`),
 		},
+		{
+			name: "with log and no logs yet (tty)",
+			tty:  true,
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				Log:         true,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					assert.Equal(t, "some-session-id", id)
+					return &capi.Session{
+						ID:        "some-session-id",
+						State:     "queued",
+						Name:      "session one",
+						CreatedAt: sampleDate,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+				m.GetSessionLogsFunc = func(_ context.Context, id string) ([]byte, error) {
+					assert.Equal(t, "some-session-id", id)
+					return []byte("  \n"), nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				No logs yet; the agent may still be starting. Try --follow.
+			`),
+		},
 		{
 			name: "with log and follow (tty)",
 			tty:  true,
@@ -1162,7 +1745,10 @@ func Test_viewRun(t *testing.T) {
 				SessionID:   "some-session-id",
 				Log:         true,
 				Follow:      true,
-				Sleep:       func(_ time.Duration) {},
+				Interval:    10 * time.Second,
+				Sleep: func(d time.Duration) {
+					assert.Equal(t, 10*time.Second, d, "expected the configured --interval to be passed to Sleep")
+				},
 			},
 			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
 				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
@@ -1181,32 +1767,116 @@ func Test_viewRun(t *testing.T) {
 				}
 
 				var count int
-				m.GetSessionLogsFunc = func(_ context.Context, id string) ([]byte, error) {
+				m.GetSessionLogsReaderFunc = func(_ context.Context, id string) (io.ReadCloser, error) {
 					assert.Equal(t, "some-session-id", id)
 
 					count++
 					require.Less(t, count, 3, "too many calls to fetch logs")
 					if count == 1 {
-						return []byte("<raw-logs-one>"), nil
+						return io.NopCloser(bytes.NewReader([]byte("<raw-logs-one>"))), nil
 					}
-					return []byte("<raw-logs-two>"), nil
+					return io.NopCloser(bytes.NewReader([]byte("<raw-logs-two>"))), nil
 				}
 			},
 			logRendererStubs: func(t *testing.T, m *shared.LogRendererMock) {
-				m.FollowFunc = func(fetcher func() ([]byte, error), w io.Writer, ios *iostreams.IOStreams) error {
-					raw, err := fetcher()
-					require.NoError(t, err)
+				m.FollowReaderFunc = func(fetcher func() (io.ReadCloser, error), w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) error {
+					for range 2 {
+						rc, err := fetcher()
+						require.NoError(t, err)
+						raw, err := io.ReadAll(rc)
+						require.NoError(t, err)
+						require.NoError(t, rc.Close())
+						w.Write([]byte("(rendered:) " + string(raw) + "\n"))
+					}
+					return nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				(rendered:) <raw-logs-one>
+				(rendered:) <raw-logs-two>
+			`),
+		},
+		{
+			// The pager is a binary that cannot be started; any attempt to
+			// start it would surface as an error on stderr.
+			name:  "with log and --no-pager, pager never started (tty)",
+			tty:   true,
+			pager: "nonexistent-pager-for-test",
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				Log:         true,
+				NoPager:     true,
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:        "some-session-id",
+						State:     "completed",
+						Name:      "session one",
+						CreatedAt: sampleDate,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+				m.GetSessionLogsFunc = func(_ context.Context, id string) ([]byte, error) {
+					return []byte("<raw-logs>"), nil
+				}
+			},
+			logRendererStubs: func(t *testing.T, m *shared.LogRendererMock) {
+				m.RenderFunc = func(raw []byte, w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) (bool, error) {
 					w.Write([]byte("(rendered:) " + string(raw) + "\n"))
-
-					raw, err = fetcher()
+					return false, nil
+				}
+			},
+			wantOut: heredoc.Doc(`
+				(rendered:) <raw-logs>
+			`),
+		},
+		{
+			// Following into a pager is broken: the pager waits for its input
+			// to end. An attempt to start the unstartable pager would surface
+			// as an error on stderr.
+			name:  "with log and follow, pager never started (tty)",
+			tty:   true,
+			pager: "nonexistent-pager-for-test",
+			opts: ViewOptions{
+				SelectorArg: "some-session-id",
+				SessionID:   "some-session-id",
+				Log:         true,
+				Follow:      true,
+				Sleep:       func(time.Duration) {},
+			},
+			capiStubs: func(t *testing.T, m *capi.CapiClientMock) {
+				m.GetSessionFunc = func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:        "some-session-id",
+						State:     "in_progress",
+						Name:      "session one",
+						CreatedAt: sampleDate,
+						User: &api.GitHubUser{
+							Login: "octocat",
+						},
+					}, nil
+				}
+				m.GetSessionLogsReaderFunc = func(_ context.Context, id string) (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader([]byte("<raw-logs>"))), nil
+				}
+			},
+			logRendererStubs: func(t *testing.T, m *shared.LogRendererMock) {
+				m.FollowReaderFunc = func(fetcher func() (io.ReadCloser, error), w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) error {
+					rc, err := fetcher()
+					require.NoError(t, err)
+					raw, err := io.ReadAll(rc)
 					require.NoError(t, err)
+					require.NoError(t, rc.Close())
 					w.Write([]byte("(rendered:) " + string(raw) + "\n"))
 					return nil
 				}
 			},
 			wantOut: heredoc.Doc(`
-				(rendered:) <raw-logs-one>
-				(rendered:) <raw-logs-two>
+				(rendered:) <raw-logs>
 			`),
 		},
 	}
@@ -1230,6 +1900,9 @@ func Test_viewRun(t *testing.T) {
 
 			ios, _, stdout, stderr := iostreams.Test()
 			ios.SetStdoutTTY(tt.tty)
+			if tt.pager != "" {
+				ios.SetPager(tt.pager)
+			}
 
 			browser := &browser.Stub{}
 
@@ -1240,7 +1913,7 @@ func Test_viewRun(t *testing.T) {
 			opts.CapiClient = func() (capi.CapiClient, error) {
 				return capiClientMock, nil
 			}
-			opts.LogRenderer = func() shared.LogRenderer {
+			opts.LogRenderer = func(_ ...shared.LogRendererOption) shared.LogRenderer {
 				return logRenderer
 			}
 
@@ -1258,3 +1931,158 @@ func Test_viewRun(t *testing.T) {
 		})
 	}
 }
+
+func Test_viewRun_json(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    string
+		wantOut string
+	}{
+		{
+			name:    "json fields",
+			args:    "00000000-0000-0000-0000-000000000000 --json id,name,state",
+			wantOut: "{\"id\":\"00000000-0000-0000-0000-000000000000\",\"name\":\"s1\",\"state\":\"completed\"}\n",
+		},
+		{
+			name:    "jq filter",
+			args:    "00000000-0000-0000-0000-000000000000 --json state --jq .state",
+			wantOut: "completed\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capiClientMock := &capi.CapiClientMock{
+				GetSessionFunc: func(_ context.Context, id string) (*capi.Session, error) {
+					return &capi.Session{
+						ID:    id,
+						Name:  "s1",
+						State: "completed",
+					}, nil
+				},
+			}
+
+			ios, _, stdout, _ := iostreams.Test()
+			f := &cmdutil.Factory{
+				IOStreams: ios,
+			}
+
+			cmd := NewCmdView(f, func(opts *ViewOptions) error {
+				opts.CapiClient = func() (capi.CapiClient, error) { return capiClientMock, nil }
+				return viewRun(opts)
+			})
+
+			argv, err := shlex.Split(tt.args)
+			require.NoError(t, err)
+			cmd.SetArgs(argv)
+
+			cmd.SetIn(&bytes.Buffer{})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			_, err = cmd.ExecuteC()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOut, stdout.String())
+		})
+	}
+}
+
+func TestViewRunEnterpriseReferenceFailsFast(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+
+	opts := &ViewOptions{
+		IO:          ios,
+		SelectorArg: "OWNER/REPO#101",
+		BaseRepo: func() (ghrepo.Interface, error) {
+			return ghrepo.NewWithHost("OWNER", "REPO", "github.example.com"), nil
+		},
+		// The unsupported host must be rejected before a CAPI client is even
+		// constructed, so no network request can be issued.
+		CapiClient: func() (capi.CapiClient, error) {
+			require.FailNow(t, "CapiClient was constructed for an unsupported host")
+			return nil, nil
+		},
+	}
+
+	err := viewRun(opts)
+	require.EqualError(t, err, "agent tasks are not supported on this host: github.example.com")
+}
+
+func TestViewRunWritesLogsToOutputFile(t *testing.T) {
+	newOpts := func(ios *iostreams.IOStreams, output string) *ViewOptions {
+		capiClientMock := &capi.CapiClientMock{
+			GetSessionFunc: func(_ context.Context, id string) (*capi.Session, error) {
+				return &capi.Session{ID: id, State: "completed", Name: "session one"}, nil
+			},
+			GetSessionLogsFunc: func(_ context.Context, _ string) ([]byte, error) {
+				return []byte("<raw-logs>"), nil
+			},
+			GetSessionLogsReaderFunc: func(_ context.Context, _ string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte("<raw-logs>"))), nil
+			},
+		}
+
+		return &ViewOptions{
+			IO:          ios,
+			SelectorArg: "some-session-id",
+			SessionID:   "some-session-id",
+			Log:         true,
+			Output:      output,
+			Sleep:       func(_ time.Duration) {},
+			CapiClient: func() (capi.CapiClient, error) {
+				return capiClientMock, nil
+			},
+			LogRenderer: func(_ ...shared.LogRendererOption) shared.LogRenderer {
+				return &shared.LogRendererMock{
+					RenderFunc: func(raw []byte, w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) (bool, error) {
+						w.Write([]byte("(rendered:) " + string(raw) + "\n"))
+						return false, nil
+					},
+					FollowReaderFunc: func(fetcher func() (io.ReadCloser, error), w io.Writer, ios *iostreams.IOStreams, filter shared.LogEntryFilter) error {
+						rc, err := fetcher()
+						require.NoError(t, err)
+						raw, err := io.ReadAll(rc)
+						require.NoError(t, err)
+						require.NoError(t, rc.Close())
+						w.Write([]byte("(rendered:) " + string(raw) + "\n"))
+						return nil
+					},
+				}
+			},
+		}
+	}
+
+	t.Run("renders into the file, not stdout", func(t *testing.T) {
+		ios, _, stdout, _ := iostreams.Test()
+		output := filepath.Join(t.TempDir(), "logs.txt")
+
+		require.NoError(t, viewRun(newOpts(ios, output)))
+
+		content, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "(rendered:) <raw-logs>\n", string(content))
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("follow streams into the file", func(t *testing.T) {
+		ios, _, stdout, _ := iostreams.Test()
+		output := filepath.Join(t.TempDir(), "logs.txt")
+
+		opts := newOpts(ios, output)
+		opts.Follow = true
+		require.NoError(t, viewRun(opts))
+
+		content, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, "(rendered:) <raw-logs>\n", string(content))
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("dash preserves writing to stdout", func(t *testing.T) {
+		ios, _, stdout, _ := iostreams.Test()
+
+		require.NoError(t, viewRun(newOpts(ios, "-")))
+
+		assert.Equal(t, "(rendered:) <raw-logs>\n", stdout.String())
+	})
+}
@@ -33,18 +33,36 @@ type httpClient interface {
 
 // GetScopes performs a GitHub API request and returns the value of the X-Oauth-Scopes header.
 func GetScopes(httpClient httpClient, hostname, authToken string) (string, error) {
+	scopes, _, err := GetScopesAndExpiration(httpClient, hostname, authToken)
+	return scopes, err
+}
+
+// GetScopesAndExpiration performs a GitHub API request and returns the values of the
+// X-Oauth-Scopes and GitHub-Authentication-Token-Expiration headers. The expiration
+// header is empty for tokens that do not expire.
+func GetScopesAndExpiration(httpClient httpClient, hostname, authToken string) (string, string, error) {
+	header, err := GetHeaders(httpClient, hostname, authToken)
+	if err != nil {
+		return "", "", err
+	}
+	return header.Get("X-Oauth-Scopes"), header.Get("GitHub-Authentication-Token-Expiration"), nil
+}
+
+// GetHeaders performs a GitHub API request and returns the response headers, which
+// carry token metadata such as OAuth scopes, expiration, and rate-limit state.
+func GetHeaders(httpClient httpClient, hostname, authToken string) (http.Header, error) {
 	apiEndpoint := ghinstance.RESTPrefix(hostname)
 
 	req, err := http.NewRequest("GET", apiEndpoint, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Set("Authorization", "token "+authToken)
 
 	res, err := httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	defer func() {
@@ -55,10 +73,10 @@ func GetScopes(httpClient httpClient, hostname, authToken string) (string, error
 	}()
 
 	if res.StatusCode != 200 {
-		return "", api.HandleHTTPError(res)
+		return nil, api.HandleHTTPError(res)
 	}
 
-	return res.Header.Get("X-Oauth-Scopes"), nil
+	return res.Header, nil
 }
 
 // HasMinimumScopes performs a GitHub API request and returns an error if the token used in the request
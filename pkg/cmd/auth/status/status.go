@@ -3,46 +3,154 @@ package status
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/v2/api"
 	"github.com/cli/cli/v2/internal/config"
 	"github.com/cli/cli/v2/internal/gh"
+	"github.com/cli/cli/v2/internal/ghinstance"
+	"github.com/cli/cli/v2/internal/text"
 	"github.com/cli/cli/v2/pkg/cmd/auth/shared"
 	"github.com/cli/cli/v2/pkg/cmdutil"
 	"github.com/cli/cli/v2/pkg/iostreams"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 type authEntryState string
 
+// defaultValidationTimeout bounds each per-account validation request so a
+// slow or unreachable host cannot hang the command indefinitely.
+const defaultValidationTimeout = 5 * time.Second
+
 const (
-	authEntryStateSuccess = "success"
-	authEntryStateTimeout = "timeout"
-	authEntryStateError   = "error"
+	authEntryStateSuccess   = "success"
+	authEntryStateTimeout   = "timeout"
+	authEntryStateError     = "error"
+	authEntryStateUnchecked = "unchecked"
 )
 
 type authEntry struct {
-	State       authEntryState `json:"state"`
-	Error       string         `json:"error,omitempty"`
-	Active      bool           `json:"active"`
-	Host        string         `json:"host"`
-	Login       string         `json:"login"`
-	TokenSource string         `json:"tokenSource"`
-	Token       string         `json:"token,omitempty"`
-	Scopes      string         `json:"scopes,omitempty"`
-	GitProtocol string         `json:"gitProtocol"`
+	State authEntryState `json:"state"`
+	Error string         `json:"error,omitempty"`
+	// Valid reports whether the entry was validated successfully, and Expired
+	// whether its token is past its expiration. Both are derived from State
+	// and ExpiresAt so scripts can branch without matching on state strings.
+	Valid       bool             `json:"valid"`
+	Expired     bool             `json:"expired"`
+	Active      bool             `json:"active"`
+	Host        string           `json:"host"`
+	Login       string           `json:"login"`
+	TokenSource string           `json:"tokenSource"`
+	Token       string           `json:"token,omitempty"`
+	Scopes      string           `json:"scopes,omitempty"`
+	Permissions string           `json:"permissions,omitempty"`
+	ExpiresAt   *time.Time       `json:"expiresAt,omitempty"`
+	GitProtocol string           `json:"gitProtocol"`
+	SSO         *ssoStatus       `json:"sso,omitempty"`
+	RateLimit   *rateLimitStatus `json:"rateLimit,omitempty"`
+}
+
+// rateLimitStatus summarizes the X-RateLimit-* headers of an authenticated
+// request.
+type rateLimitStatus struct {
+	Remaining int        `json:"remaining"`
+	Limit     int        `json:"limit"`
+	ResetsAt  *time.Time `json:"resetsAt,omitempty"`
+}
+
+func (r rateLimitStatus) String() string {
+	s := fmt.Sprintf("%d/%d", r.Remaining, r.Limit)
+	if r.ResetsAt != nil {
+		s += fmt.Sprintf(", resets in %s", text.FuzzyAgoAbbr(*r.ResetsAt, time.Now()))
+	}
+	return s
+}
+
+// parseRateLimitHeaders reads the X-RateLimit-* response headers. Proxies and
+// some GitHub Enterprise configurations strip them, in which case nil is
+// returned and no rate-limit information is reported.
+func parseRateLimitHeaders(header http.Header) *rateLimitStatus {
+	remaining, err := strconv.Atoi(header.Get("X-Ratelimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+	limit, err := strconv.Atoi(header.Get("X-Ratelimit-Limit"))
+	if err != nil {
+		return nil
+	}
+	status := &rateLimitStatus{Remaining: remaining, Limit: limit}
+	if reset, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		resetsAt := time.Unix(reset, 0)
+		status.ResetsAt = &resetsAt
+	}
+	return status
 }
 
+// ssoStatus summarizes the X-GitHub-SSO header of an authenticated request.
+type ssoStatus struct {
+	// Authorized is false when one or more organizations enforce SAML SSO
+	// and the token has not been authorized for them.
+	Authorized bool `json:"authorized"`
+	// Organizations holds the IDs of organizations for which the token has
+	// not been authorized.
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+func (s ssoStatus) String() string {
+	if s.Authorized {
+		return "authorized"
+	}
+	if len(s.Organizations) > 0 {
+		return fmt.Sprintf("required for organization IDs %s", strings.Join(s.Organizations, ", "))
+	}
+	return "required"
+}
+
+// parseSSOHeader parses the X-GitHub-SSO response header. The header takes
+// one of two forms:
+//
+//	required; url=https://github.com/orgs/ORG/sso?authorization_request=...
+//	partial-results; organizations=123,456
+//
+// In the partial-results form, the listed organization IDs enforce SAML SSO
+// and the token is not authorized for them. An empty header means the token
+// is authorized for every organization that requires it.
+func parseSSOHeader(header string) *ssoStatus {
+	status := &ssoStatus{Authorized: true}
+	for _, part := range strings.Split(header, ";") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "required":
+			status.Authorized = false
+		case strings.HasPrefix(part, "organizations="):
+			if orgs := strings.TrimPrefix(part, "organizations="); orgs != "" {
+				status.Organizations = strings.Split(orgs, ",")
+				status.Authorized = false
+			}
+		}
+	}
+	return status
+}
+
+// authStatus is the machine-readable shape of `gh auth status --json`:
+// always an object keyed by hostname mapping to arrays of account entries,
+// so consumers get the same schema whether zero, one, or many hosts are
+// configured.
 type authStatus struct {
 	Hosts map[string][]authEntry `json:"hosts"`
 }
 
+// newAuthStatus allocates the hosts map up front so an empty status still
+// serializes as {"hosts":{}} rather than {"hosts":null}.
 func newAuthStatus() *authStatus {
 	return &authStatus{
 		Hosts: make(map[string][]authEntry),
@@ -70,7 +178,30 @@ func (e authEntry) String(cs *iostreams.ColorScheme) string {
 		sb.WriteString(fmt.Sprintf("  - Git operations protocol: %s\n", cs.Bold(e.GitProtocol)))
 		sb.WriteString(fmt.Sprintf("  - Token: %s\n", cs.Bold(e.Token)))
 
-		if expectScopes(e.Token) {
+		if e.ExpiresAt != nil {
+			now := time.Now()
+			switch {
+			case e.ExpiresAt.Before(now):
+				sb.WriteString(fmt.Sprintf("  %s Token expired %s\n", cs.WarningIcon(), text.FuzzyAgo(now, *e.ExpiresAt)))
+			case e.ExpiresAt.Before(now.AddDate(0, 0, 30)):
+				sb.WriteString(fmt.Sprintf("  %s Token expires: %s\n", cs.WarningIcon(), cs.Bold(e.ExpiresAt.Format(time.DateOnly))))
+			default:
+				sb.WriteString(fmt.Sprintf("  - Token expires: %s\n", cs.Bold(e.ExpiresAt.Format(time.DateOnly))))
+			}
+		}
+
+		if e.SSO != nil {
+			sb.WriteString(fmt.Sprintf("  - SSO authorization: %s\n", cs.Bold(e.SSO.String())))
+		}
+
+		if isFineGrainedToken(e.Token) {
+			sb.WriteString(fmt.Sprintf("  - Token permissions: %s\n", cs.Bold(displayPermissions(e.Permissions))))
+		} else if isInstallationToken(e.Token) {
+			// Installation tokens carry the app's installation permissions,
+			// which the API does not report; note that instead of printing
+			// an empty or misleading "none" scopes line.
+			sb.WriteString(fmt.Sprintf("  - Token scopes: %s\n", cs.Bold("installation token; scopes not applicable")))
+		} else if expectScopes(e.Token) {
 			sb.WriteString(fmt.Sprintf("  - Token scopes: %s\n", cs.Bold(displayScopes(e.Scopes))))
 			if err := shared.HeaderHasMinimumScopes(e.Scopes); err != nil {
 				var missingScopesError *shared.MissingScopesError
@@ -85,6 +216,10 @@ func (e authEntry) String(cs *iostreams.ColorScheme) string {
 			}
 		}
 
+		if e.RateLimit != nil {
+			sb.WriteString(fmt.Sprintf("  - API rate limit: %s\n", cs.Bold(e.RateLimit.String())))
+		}
+
 	case authEntryStateError:
 		if e.Login != "" {
 			sb.WriteString(fmt.Sprintf("  %s Failed to log in to %s account %s (%s)\n", cs.Red("X"), e.Host, cs.Bold(e.Login), e.TokenSource))
@@ -101,6 +236,17 @@ func (e authEntry) String(cs *iostreams.ColorScheme) string {
 			sb.WriteString(fmt.Sprintf("  - To forget about this account, run: %s\n", cs.Bold(logoutInstructions)))
 		}
 
+	case authEntryStateUnchecked:
+		if e.Login != "" {
+			sb.WriteString(fmt.Sprintf("  - Logged in to %s account %s (%s); token not validated\n", e.Host, cs.Bold(e.Login), e.TokenSource))
+		} else {
+			sb.WriteString(fmt.Sprintf("  - Logged in to %s using token (%s); token not validated\n", e.Host, e.TokenSource))
+		}
+		activeStr := fmt.Sprintf("%v", e.Active)
+		sb.WriteString(fmt.Sprintf("  - Active account: %s\n", cs.Bold(activeStr)))
+		sb.WriteString(fmt.Sprintf("  - Git operations protocol: %s\n", cs.Bold(e.GitProtocol)))
+		sb.WriteString(fmt.Sprintf("  - Token: %s\n", cs.Bold(e.Token)))
+
 	case authEntryStateTimeout:
 		if e.Login != "" {
 			sb.WriteString(fmt.Sprintf("  %s Timeout trying to log in to %s account %s (%s)\n", cs.Red("X"), e.Host, cs.Bold(e.Login), e.TokenSource))
@@ -120,9 +266,13 @@ type StatusOptions struct {
 	Config     func() (gh.Config, error)
 	Exporter   cmdutil.Exporter
 
-	Hostname  string
-	ShowToken bool
-	Active    bool
+	Hostname   string
+	ShowToken  bool
+	Active     bool
+	CheckSSO   bool
+	NoValidate bool
+	Timeout    time.Duration
+	Quiet      bool
 }
 
 func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Command {
@@ -146,6 +296,9 @@ func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Co
 			the command will exit with 1 and output to stderr. Note that when using the %[1]s--json%[1]s option, the command
 			will always exit with zero regardless of any authentication issues, unless there is a fatal error.
 
+			With %[1]s--quiet%[1]s, no status is printed and the exit status alone reports whether
+			the checked accounts are valid, which is useful for health checks and scripting.
+
 			To change the active account for a host, see %[1]sgh auth switch%[1]s.
 		`, "`"),
 		Example: heredoc.Doc(`
@@ -168,6 +321,22 @@ func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Co
 			$ gh auth status --json hosts --jq '.hosts | add'
 		`),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmdutil.MutuallyExclusive(
+				"specify only one of `--no-validate` or `--check-sso`",
+				opts.NoValidate,
+				opts.CheckSSO,
+			); err != nil {
+				return err
+			}
+
+			if err := cmdutil.MutuallyExclusive(
+				"specify only one of `--quiet` or `--json`",
+				opts.Quiet,
+				opts.Exporter != nil,
+			); err != nil {
+				return err
+			}
+
 			if runF != nil {
 				return runF(opts)
 			}
@@ -179,6 +348,10 @@ func NewCmdStatus(f *cmdutil.Factory, runF func(*StatusOptions) error) *cobra.Co
 	cmd.Flags().StringVarP(&opts.Hostname, "hostname", "h", "", "Check only a specific hostname's auth status")
 	cmd.Flags().BoolVarP(&opts.ShowToken, "show-token", "t", false, "Display the auth token")
 	cmd.Flags().BoolVarP(&opts.Active, "active", "a", false, "Display the active account only")
+	cmd.Flags().BoolVar(&opts.CheckSSO, "check-sso", false, "Check which organizations require SAML SSO authorization for the active token")
+	cmd.Flags().BoolVar(&opts.NoValidate, "no-validate", false, "Report configured accounts without contacting the host to validate tokens")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", defaultValidationTimeout, "Maximum time to wait for each token validation request, as a `duration`")
+	cmd.Flags().BoolVar(&opts.Quiet, "quiet", false, "Validate accounts and exit with a status code, printing no status output")
 
 	// the json flags are intentionally not given a shorthand to avoid conflict with -t/--show-token
 	cmdutil.AddJSONFlagsWithoutShorthand(cmd, &opts.Exporter, authStatusFields)
@@ -225,8 +398,20 @@ func statusRun(opts *StatusOptions) error {
 		return err
 	}
 
-	var finalErr error
-	statuses := newAuthStatus()
+	// Bound each validation request so a slow host cannot hang the command.
+	// The client-level timeout surfaces as a net.Error timeout, which
+	// buildEntry maps to the timeout state.
+	if opts.Timeout > 0 {
+		timeoutClient := *httpClient
+		timeoutClient.Timeout = opts.Timeout
+		httpClient = &timeoutClient
+	}
+
+	type entryJob struct {
+		hostname string
+		opts     buildEntryOptions
+	}
+	var jobs []entryJob
 
 	for _, hostname := range hostnames {
 		if opts.Hostname != "" && opts.Hostname != hostname {
@@ -239,19 +424,16 @@ func statusRun(opts *StatusOptions) error {
 		if authTokenWriteable(activeUserTokenSource) {
 			activeUser, _ = authCfg.ActiveUser(hostname)
 		}
-		entry := buildEntry(httpClient, buildEntryOptions{
+		jobs = append(jobs, entryJob{hostname, buildEntryOptions{
 			active:      true,
+			checkSSO:    opts.CheckSSO,
+			noValidate:  opts.NoValidate,
 			gitProtocol: gitProtocol,
 			hostname:    hostname,
 			token:       activeUserToken,
 			tokenSource: activeUserTokenSource,
 			username:    activeUser,
-		})
-		statuses.Hosts[hostname] = append(statuses.Hosts[hostname], entry)
-
-		if finalErr == nil && entry.State != authEntryStateSuccess {
-			finalErr = cmdutil.SilentError
-		}
+		}})
 
 		if opts.Active {
 			continue
@@ -263,22 +445,48 @@ func statusRun(opts *StatusOptions) error {
 				continue
 			}
 			token, tokenSource, _ := authCfg.TokenForUser(hostname, username)
-			entry := buildEntry(httpClient, buildEntryOptions{
+			jobs = append(jobs, entryJob{hostname, buildEntryOptions{
 				active:      false,
+				noValidate:  opts.NoValidate,
 				gitProtocol: gitProtocol,
 				hostname:    hostname,
 				token:       token,
 				tokenSource: tokenSource,
 				username:    username,
-			})
-			statuses.Hosts[hostname] = append(statuses.Hosts[hostname], entry)
+			}})
+		}
+	}
 
-			if finalErr == nil && entry.State != authEntryStateSuccess {
-				finalErr = cmdutil.SilentError
-			}
+	// Validate accounts concurrently; results keep the deterministic job order
+	// above regardless of completion order.
+	entries := make([]authEntry, len(jobs))
+	var g errgroup.Group
+	g.SetLimit(5)
+	for i, job := range jobs {
+		g.Go(func() error {
+			entries[i] = buildEntry(httpClient, job.opts)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var finalErr error
+	statuses := newAuthStatus()
+	for i, job := range jobs {
+		entry := entries[i]
+		entry.Valid = entry.State == authEntryStateSuccess
+		entry.Expired = entry.ExpiresAt != nil && entry.ExpiresAt.Before(time.Now())
+		statuses.Hosts[job.hostname] = append(statuses.Hosts[job.hostname], entry)
+
+		if finalErr == nil && entry.State != authEntryStateSuccess && entry.State != authEntryStateUnchecked {
+			finalErr = cmdutil.SilentError
 		}
 	}
 
+	if opts.Quiet {
+		return finalErr
+	}
+
 	if !opts.ShowToken {
 		for _, host := range statuses.Hosts {
 			for i := range host {
@@ -323,13 +531,50 @@ func statusRun(opts *StatusOptions) error {
 		}
 	}
 
+	// With several accounts listed, a trailing summary makes overall health
+	// visible at a glance. Single-host or active-only output is short enough
+	// already, and unvalidated entries have nothing to summarize.
+	if !opts.Active && opts.Hostname == "" && !opts.NoValidate {
+		var total, healthy int
+		for _, host := range statuses.Hosts {
+			for _, entry := range host {
+				total++
+				if entry.Valid {
+					healthy++
+				}
+			}
+		}
+		if total > 1 {
+			stream := stdout
+			if finalErr != nil {
+				stream = stderr
+			}
+			summary := fmt.Sprintf("%d of %s OK", healthy, text.Pluralize(total, "account"))
+			if unhealthy := total - healthy; unhealthy > 0 {
+				verb := "need"
+				if unhealthy == 1 {
+					verb = "needs"
+				}
+				summary = fmt.Sprintf("%s; %d %s re-authentication", summary, unhealthy, verb)
+			}
+			fmt.Fprintf(stream, "\n%s\n", summary)
+		}
+	}
+
 	return finalErr
 }
 
+// Token prefixes that are safe to leave unmasked. Matching on known prefixes
+// rather than splitting on the last underscore keeps fine-grained tokens like
+// "github_pat_XX_YY" from leaking the random part before their last
+// underscore.
+var tokenPrefixes = []string{"ghp_", "gho_", "ghs_", "github_pat_"}
+
 func maskToken(token string) string {
-	if idx := strings.LastIndexByte(token, '_'); idx > -1 {
-		prefix := token[0 : idx+1]
-		return prefix + strings.Repeat("*", len(token)-len(prefix))
+	for _, prefix := range tokenPrefixes {
+		if strings.HasPrefix(token, prefix) {
+			return prefix + strings.Repeat("*", len(token)-len(prefix))
+		}
 	}
 	return strings.Repeat("*", len(token))
 }
@@ -345,12 +590,37 @@ func displayScopes(scopes string) string {
 	return strings.Join(list, ", ")
 }
 
+// displayPermissions formats a fine-grained token's resource permissions for
+// display. The API does not currently report which permissions a token
+// carries, so in practice this notes that OAuth scopes do not apply.
+func displayPermissions(permissions string) string {
+	if permissions == "" {
+		return "fine-grained token; scopes not applicable"
+	}
+	return displayScopes(permissions)
+}
+
 func expectScopes(token string) bool {
 	return strings.HasPrefix(token, "ghp_") || strings.HasPrefix(token, "gho_")
 }
 
+// isFineGrainedToken reports whether token is a fine-grained personal access
+// token, which carries resource permissions rather than OAuth scopes.
+func isFineGrainedToken(token string) bool {
+	return strings.HasPrefix(token, "github_pat_")
+}
+
+// isInstallationToken reports whether token is a GitHub App installation
+// (server-to-server) token, which carries the installation's permissions
+// rather than OAuth scopes.
+func isInstallationToken(token string) bool {
+	return strings.HasPrefix(token, "ghs_")
+}
+
 type buildEntryOptions struct {
 	active      bool
+	checkSSO    bool
+	noValidate  bool
 	gitProtocol string
 	hostname    string
 	token       string
@@ -374,6 +644,13 @@ func buildEntry(httpClient *http.Client, opts buildEntryOptions) authEntry {
 		GitProtocol: opts.gitProtocol,
 	}
 
+	// Without validation there is nothing to check over the network; report
+	// the account as configured but unchecked.
+	if opts.noValidate {
+		entry.State = authEntryStateUnchecked
+		return entry
+	}
+
 	// If token is not writeable, then it came from an environment variable and
 	// we need to fetch the username as it won't be stored in the config.
 	if !authTokenWriteable(tokenSource) {
@@ -389,8 +666,8 @@ func buildEntry(httpClient *http.Client, opts buildEntryOptions) authEntry {
 		}
 	}
 
-	// Get scopes for token.
-	scopesHeader, err := shared.GetScopes(httpClient, opts.hostname, opts.token)
+	// Get scopes, expiration, and rate-limit state for token.
+	headers, err := shared.GetHeaders(httpClient, opts.hostname, opts.token)
 	if err != nil {
 		var networkError net.Error
 		if errors.As(err, &networkError) && networkError.Timeout() {
@@ -403,12 +680,65 @@ func buildEntry(httpClient *http.Client, opts buildEntryOptions) authEntry {
 		entry.Error = err.Error()
 		return entry
 	}
-	entry.Scopes = scopesHeader
+	entry.Scopes = headers.Get("X-Oauth-Scopes")
+	entry.ExpiresAt = parseExpirationHeader(headers.Get("GitHub-Authentication-Token-Expiration"))
+	entry.RateLimit = parseRateLimitHeaders(headers)
+
+	if opts.checkSSO {
+		ssoHeader, err := getSSOHeader(httpClient, opts.hostname, opts.token)
+		if err != nil {
+			entry.State = authEntryStateError
+			entry.Error = err.Error()
+			return entry
+		}
+		entry.SSO = parseSSOHeader(ssoHeader)
+	}
 
 	entry.State = authEntryStateSuccess
 	return entry
 }
 
+// parseExpirationHeader parses the GitHub-Authentication-Token-Expiration
+// response header, e.g. "2024-01-15 15:04:05 UTC". Tokens without an
+// expiration, and headers in an unrecognized format, yield nil.
+func parseExpirationHeader(header string) *time.Time {
+	if header == "" {
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", time.RFC3339} {
+		if t, err := time.Parse(layout, header); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
+
+// getSSOHeader makes an authenticated request and returns the value of the
+// X-GitHub-SSO response header. The header is present on both successful and
+// SSO-blocked responses, so the status code is intentionally not checked.
+func getSSOHeader(httpClient *http.Client, hostname, authToken string) (string, error) {
+	apiEndpoint := ghinstance.RESTPrefix(hostname) + "user/orgs"
+
+	req, err := http.NewRequest("GET", apiEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Authorization", "token "+authToken)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}()
+
+	return res.Header.Get("X-GitHub-SSO"), nil
+}
+
 func authTokenWriteable(src string) bool {
 	return !strings.HasSuffix(src, "_TOKEN")
 }
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/cli/v2/internal/config"
@@ -28,15 +29,18 @@ func Test_NewCmdStatus(t *testing.T) {
 		wants StatusOptions
 	}{
 		{
-			name:  "no arguments",
-			cli:   "",
-			wants: StatusOptions{},
+			name: "no arguments",
+			cli:  "",
+			wants: StatusOptions{
+				Timeout: defaultValidationTimeout,
+			},
 		},
 		{
 			name: "hostname set",
 			cli:  "--hostname ellie.williams",
 			wants: StatusOptions{
 				Hostname: "ellie.williams",
+				Timeout:  defaultValidationTimeout,
 			},
 		},
 		{
@@ -44,13 +48,22 @@ func Test_NewCmdStatus(t *testing.T) {
 			cli:  "--show-token",
 			wants: StatusOptions{
 				ShowToken: true,
+				Timeout:   defaultValidationTimeout,
 			},
 		},
 		{
 			name: "active",
 			cli:  "--active",
 			wants: StatusOptions{
-				Active: true,
+				Active:  true,
+				Timeout: defaultValidationTimeout,
+			},
+		},
+		{
+			name: "timeout set",
+			cli:  "--timeout 10s",
+			wants: StatusOptions{
+				Timeout: 10 * time.Second,
 			},
 		},
 	}
@@ -82,6 +95,7 @@ func Test_NewCmdStatus(t *testing.T) {
 			assert.Equal(t, tt.wants.Hostname, gotOpts.Hostname)
 			assert.Equal(t, tt.wants.ShowToken, gotOpts.ShowToken)
 			assert.Equal(t, tt.wants.Active, gotOpts.Active)
+			assert.Equal(t, tt.wants.Timeout, gotOpts.Timeout)
 		})
 	}
 }
@@ -125,6 +139,33 @@ func Test_statusRun(t *testing.T) {
 				  - Active account: true
 			`),
 		},
+		{
+			name: "slow transport exceeds --timeout",
+			opts: StatusOptions{
+				Hostname: "github.com",
+				Timeout:  100 * time.Millisecond,
+			},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", ""), func(req *http.Request) (*http.Response, error) {
+					// simulate a host that responds slower than the configured timeout
+					select {
+					case <-req.Context().Done():
+						return nil, req.Context().Err()
+					case <-time.After(5 * time.Second):
+						return httpmock.ScopesResponder("repo,read:org")(req)
+					}
+				})
+			},
+			wantErr: cmdutil.SilentError,
+			wantErrOut: heredoc.Doc(`
+				github.com
+				  X Timeout trying to log in to github.com account monalisa (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+			`),
+		},
 		{
 			name: "hostname set",
 			opts: StatusOptions{
@@ -168,6 +209,168 @@ func Test_statusRun(t *testing.T) {
 				  - To request missing scopes, run: gh auth refresh -h ghe.io
 			`),
 		},
+		{
+			name: "check SSO authorization",
+			opts: StatusOptions{
+				CheckSSO: true,
+			},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "ghe.io", "monalisa-ghe", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				// mocks for HeaderHasMinimumScopes api requests to a non-github.com host
+				reg.Register(httpmock.REST("GET", "api/v3/"), httpmock.ScopesResponder("repo,read:org"))
+				reg.Register(
+					httpmock.REST("GET", "api/v3/user/orgs"),
+					httpmock.WithHeader(httpmock.StatusStringResponse(200, "[]"), "X-GitHub-SSO", "partial-results; organizations=123,456"),
+				)
+			},
+			wantOut: heredoc.Doc(`
+				ghe.io
+				  ✓ Logged in to ghe.io account monalisa-ghe (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+				  - SSO authorization: required for organization IDs 123, 456
+				  - Token scopes: 'repo', 'read:org'
+			`),
+		},
+		{
+			name: "token with expiration",
+			opts: StatusOptions{},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				// mocks for HeaderHasMinimumScopes api requests to github.com
+				reg.Register(
+					httpmock.REST("GET", ""),
+					httpmock.WithHeader(
+						httpmock.ScopesResponder("repo,read:org"),
+						"GitHub-Authentication-Token-Expiration", "2099-01-15 15:04:05 UTC"))
+			},
+			wantOut: heredoc.Doc(`
+				github.com
+				  ✓ Logged in to github.com account monalisa (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+				  - Token expires: 2099-01-15
+				  - Token scopes: 'repo', 'read:org'
+			`),
+		},
+		{
+			name: "no validation",
+			opts: StatusOptions{
+				NoValidate: true,
+			},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+				login(t, c, "ghe.io", "monalisa-ghe", "gho_abc123", "https")
+			},
+			wantOut: heredoc.Doc(`
+				github.com
+				  - Logged in to github.com account monalisa (GH_CONFIG_DIR/hosts.yml); token not validated
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+
+				ghe.io
+				  - Logged in to ghe.io account monalisa-ghe (GH_CONFIG_DIR/hosts.yml); token not validated
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+			`),
+		},
+		{
+			name: "rate limit reporting",
+			opts: StatusOptions{},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				// mocks for HeaderHasMinimumScopes api requests to github.com;
+				// no reset header, as a proxy might strip it
+				reg.Register(
+					httpmock.REST("GET", ""),
+					httpmock.WithHeader(
+						httpmock.WithHeader(
+							httpmock.ScopesResponder("repo,read:org"),
+							"X-Ratelimit-Remaining", "4823"),
+						"X-Ratelimit-Limit", "5000"))
+			},
+			wantOut: heredoc.Doc(`
+				github.com
+				  ✓ Logged in to github.com account monalisa (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+				  - Token scopes: 'repo', 'read:org'
+				  - API rate limit: 4823/5000
+			`),
+		},
+		{
+			name: "quiet with valid token",
+			opts: StatusOptions{
+				Hostname: "github.com",
+				Quiet:    true,
+			},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				// mocks for HeaderHasMinimumScopes api requests to github.com
+				reg.Register(httpmock.REST("GET", ""), httpmock.ScopesResponder("repo,read:org"))
+			},
+		},
+		{
+			name: "quiet with invalid token",
+			opts: StatusOptions{
+				Hostname: "github.com",
+				Quiet:    true,
+			},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", ""), httpmock.StatusStringResponse(400, "no bueno"))
+			},
+			wantErr: cmdutil.SilentError,
+		},
+		{
+			name: "stable ordering when validation completes out of order",
+			opts: StatusOptions{},
+			cfgStubs: func(t *testing.T, c gh.Config) {
+				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
+				login(t, c, "ghe.io", "monalisa-ghe", "gho_abc123", "https")
+			},
+			httpStubs: func(reg *httpmock.Registry) {
+				// github.com responds last but is still printed first
+				reg.Register(httpmock.REST("GET", ""), func(req *http.Request) (*http.Response, error) {
+					time.Sleep(100 * time.Millisecond)
+					return httpmock.ScopesResponder("repo,read:org")(req)
+				})
+				// mocks for HeaderHasMinimumScopes api requests to a non-github.com host
+				reg.Register(httpmock.REST("GET", "api/v3/"), httpmock.ScopesResponder("repo,read:org"))
+			},
+			wantOut: heredoc.Doc(`
+				github.com
+				  ✓ Logged in to github.com account monalisa (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+				  - Token scopes: 'repo', 'read:org'
+
+				ghe.io
+				  ✓ Logged in to ghe.io account monalisa-ghe (GH_CONFIG_DIR/hosts.yml)
+				  - Active account: true
+				  - Git operations protocol: https
+				  - Token: gho_******
+				  - Token scopes: 'repo', 'read:org'
+				
+				2 of 2 accounts OK
+			`),
+		},
 		{
 			name: "bad token",
 			opts: StatusOptions{},
@@ -264,6 +467,8 @@ func Test_statusRun(t *testing.T) {
 				  - Git operations protocol: ssh
 				  - Token: gho_******
 				  - Token scopes: none
+				
+				2 of 2 accounts OK
 			`),
 		},
 		{
@@ -306,6 +511,7 @@ func Test_statusRun(t *testing.T) {
 				  - Active account: true
 				  - Git operations protocol: https
 				  - Token: ghs_******
+				  - Token scopes: installation token; scopes not applicable
 			`),
 		},
 		{
@@ -326,6 +532,7 @@ func Test_statusRun(t *testing.T) {
 				  - Active account: true
 				  - Git operations protocol: https
 				  - Token: github_pat_******
+				  - Token permissions: fine-grained token; scopes not applicable
 			`),
 		},
 		{
@@ -357,6 +564,8 @@ func Test_statusRun(t *testing.T) {
 				  - Git operations protocol: https
 				  - Token: gho_xyz456
 				  - Token scopes: 'repo', 'read:org'
+				
+				2 of 2 accounts OK
 			`),
 		},
 		{
@@ -376,11 +585,11 @@ func Test_statusRun(t *testing.T) {
 			opts: StatusOptions{},
 			cfgStubs: func(t *testing.T, c gh.Config) {
 				login(t, c, "github.com", "monalisa", "gho_abc123", "https")
-				login(t, c, "github.com", "monalisa-2", "gho_abc123", "https")
+				login(t, c, "github.com", "monalisa-2", "gho_def456", "https")
 			},
 			httpStubs: func(reg *httpmock.Registry) {
-				reg.Register(httpmock.REST("GET", ""), httpmock.ScopesResponder("repo,read:org"))
-				reg.Register(httpmock.REST("GET", ""), httpmock.ScopesResponder("repo,read:org,project:read"))
+				reg.Register(restWithToken("GET", "", "gho_def456"), httpmock.ScopesResponder("repo,read:org"))
+				reg.Register(restWithToken("GET", "", "gho_abc123"), httpmock.ScopesResponder("repo,read:org,project:read"))
 			},
 			wantOut: heredoc.Doc(`
 				github.com
@@ -395,6 +604,8 @@ func Test_statusRun(t *testing.T) {
 				  - Git operations protocol: https
 				  - Token: gho_******
 				  - Token scopes: 'repo', 'read:org', 'project:read'
+				
+				2 of 2 accounts OK
 			`),
 		},
 		{
@@ -408,13 +619,13 @@ func Test_statusRun(t *testing.T) {
 			},
 			httpStubs: func(reg *httpmock.Registry) {
 				// Get scopes for monalisa-2
-				reg.Register(httpmock.REST("GET", ""), httpmock.ScopesResponder("repo,read:org"))
+				reg.Register(restWithToken("GET", "", "gho_ghi789"), httpmock.ScopesResponder("repo,read:org"))
 				// Get scopes for monalisa
-				reg.Register(httpmock.REST("GET", ""), httpmock.ScopesResponder("repo"))
+				reg.Register(restWithToken("GET", "", "gho_def456"), httpmock.ScopesResponder("repo"))
 				// Get scopes for monalisa-ghe-2
-				reg.Register(httpmock.REST("GET", "api/v3/"), httpmock.ScopesResponder("repo,read:org"))
+				reg.Register(restWithToken("GET", "api/v3/", "gho_abc123"), httpmock.ScopesResponder("repo,read:org"))
 				// Error getting scopes for monalisa-ghe
-				reg.Register(httpmock.REST("GET", "api/v3/"), httpmock.StatusStringResponse(404, "{}"))
+				reg.Register(restWithToken("GET", "api/v3/", "gho_xyz123"), httpmock.StatusStringResponse(404, "{}"))
 				// Get username for monalisa-ghe-2
 				reg.Register(
 					httpmock.GraphQL(`query UserCurrent\b`),
@@ -449,6 +660,8 @@ func Test_statusRun(t *testing.T) {
 				  - The token in GH_CONFIG_DIR/hosts.yml is invalid.
 				  - To re-authenticate, run: gh auth login -h ghe.io
 				  - To forget about this account, run: gh auth logout -h ghe.io -u monalisa-ghe
+				
+				3 of 4 accounts OK; 1 needs re-authentication
 			`),
 		},
 		{
@@ -583,7 +796,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.REST("GET", "api/v3/"),
 					httpmock.WithHeader(httpmock.ScopesResponder("repo,read:org"), "X-Oauth-Scopes", "repo, read:org"))
 			},
-			wantOut: `{"hosts":{"ghe.io":[{"state":"success","active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}],"github.com":[{"state":"success","active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"},{"state":"success","active":false,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"ghe.io":[{"state":"success","valid":true,"expired":false,"active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}],"github.com":[{"state":"success","valid":true,"expired":false,"active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"},{"state":"success","valid":true,"expired":false,"active":false,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
 		},
 		{
 			name: "json, all valid tokens with hostname",
@@ -605,7 +818,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.REST("GET", ""),
 					httpmock.WithHeader(httpmock.ScopesResponder("repo,read:org"), "X-Oauth-Scopes", "repo, read:org"))
 			},
-			wantOut: `{"hosts":{"github.com":[{"state":"success","active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"},{"state":"success","active":false,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"github.com":[{"state":"success","valid":true,"expired":false,"active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"},{"state":"success","valid":true,"expired":false,"active":false,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
 		},
 		{
 			name: "json, all valid tokens with active",
@@ -627,7 +840,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.REST("GET", "api/v3/"),
 					httpmock.WithHeader(httpmock.ScopesResponder("repo,read:org"), "X-Oauth-Scopes", "repo, read:org"))
 			},
-			wantOut: `{"hosts":{"ghe.io":[{"state":"success","active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}],"github.com":[{"state":"success","active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"ghe.io":[{"state":"success","valid":true,"expired":false,"active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}],"github.com":[{"state":"success","valid":true,"expired":false,"active":true,"host":"github.com","login":"monalisa2","tokenSource":"GH_CONFIG_DIR/hosts.yml","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
 		},
 		{
 			name:       "json, token from env",
@@ -642,7 +855,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.GraphQL(`query UserCurrent\b`),
 					httpmock.StringResponse(`{"data":{"viewer":{"login":"monalisa"}}}`))
 			},
-			wantOut: `{"hosts":{"github.com":[{"state":"success","active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_TOKEN","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"github.com":[{"state":"success","valid":true,"expired":false,"active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_TOKEN","gitProtocol":"https"}]}}` + "\n",
 		},
 		{
 			name:       "json, bad token",
@@ -655,7 +868,7 @@ func Test_statusRun(t *testing.T) {
 				// mock for HeaderHasMinimumScopes api requests to a non-github.com host
 				reg.Register(httpmock.REST("GET", "api/v3/"), httpmock.StatusStringResponse(400, "no bueno"))
 			},
-			wantOut: `{"hosts":{"ghe.io":[{"state":"error","error":"HTTP 400 (https://ghe.io/api/v3/)","active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"ghe.io":[{"state":"error","error":"HTTP 400 (https://ghe.io/api/v3/)","valid":false,"expired":false,"active":true,"host":"ghe.io","login":"monalisa-ghe","tokenSource":"GH_CONFIG_DIR/hosts.yml","gitProtocol":"https"}]}}` + "\n",
 			wantErr: nil, // should not return error in machine-readable mode
 		},
 		{
@@ -669,7 +882,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.GraphQL(`query UserCurrent\b`),
 					httpmock.StatusStringResponse(400, `no bueno`))
 			},
-			wantOut: `{"hosts":{"github.com":[{"state":"error","error":"non-200 OK status code:  body: \"no bueno\"","active":true,"host":"github.com","login":"","tokenSource":"GH_TOKEN","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"github.com":[{"state":"error","error":"non-200 OK status code:  body: \"no bueno\"","valid":false,"expired":false,"active":true,"host":"github.com","login":"","tokenSource":"GH_TOKEN","gitProtocol":"https"}]}}` + "\n",
 			wantErr: nil, // should not return error in machine-readable mode
 		},
 		{
@@ -687,7 +900,7 @@ func Test_statusRun(t *testing.T) {
 					return nil, context.DeadlineExceeded
 				})
 			},
-			wantOut: `{"hosts":{"github.com":[{"state":"timeout","error":"Get \"https://api.github.com/\": context deadline exceeded","active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"github.com":[{"state":"timeout","error":"Get \"https://api.github.com/\": context deadline exceeded","valid":false,"expired":false,"active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","gitProtocol":"https"}]}}` + "\n",
 			wantErr: nil, // should not return error in machine-readable mode
 		},
 		{
@@ -706,7 +919,7 @@ func Test_statusRun(t *testing.T) {
 					httpmock.REST("GET", ""),
 					httpmock.WithHeader(httpmock.ScopesResponder("repo,read:org"), "X-Oauth-Scopes", "repo, read:org"))
 			},
-			wantOut: `{"hosts":{"github.com":[{"state":"success","active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","token":"abc123","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
+			wantOut: `{"hosts":{"github.com":[{"state":"success","valid":true,"expired":false,"active":true,"host":"github.com","login":"monalisa","tokenSource":"GH_CONFIG_DIR/hosts.yml","token":"abc123","scopes":"repo, read:org","gitProtocol":"https"}]}}` + "\n",
 		},
 	}
 
@@ -761,6 +974,14 @@ func Test_statusRun(t *testing.T) {
 	}
 }
 
+// restWithToken matches a REST request made with a specific token, so stubs
+// stay paired with the right account when entries are validated concurrently.
+func restWithToken(method, path, token string) httpmock.Matcher {
+	return func(req *http.Request) bool {
+		return httpmock.REST(method, path)(req) && req.Header.Get("Authorization") == "token "+token
+	}
+}
+
 func login(t *testing.T, c gh.Config, hostname, username, token, protocol string) {
 	t.Helper()
 	_, err := c.Authentication().Login(hostname, username, token, protocol, false)
@@ -782,3 +1003,173 @@ func replaceAll(s string, old string, new string) string {
 	replaced = strings.ReplaceAll(replaced, old, new)
 	return replaced
 }
+
+func TestParseSSOHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   *ssoStatus
+	}{
+		{
+			name:   "empty header",
+			header: "",
+			want:   &ssoStatus{Authorized: true},
+		},
+		{
+			name:   "required",
+			header: "required; url=https://github.com/orgs/acme/sso?authorization_request=ABC123",
+			want:   &ssoStatus{Authorized: false},
+		},
+		{
+			name:   "partial results",
+			header: "partial-results; organizations=21955855,20582480",
+			want: &ssoStatus{
+				Authorized:    false,
+				Organizations: []string{"21955855", "20582480"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseSSOHeader(tt.header))
+		})
+	}
+}
+
+func TestParseExpirationHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   *time.Time
+	}{
+		{
+			name:   "empty header",
+			header: "",
+			want:   nil,
+		},
+		{
+			name:   "UTC timestamp",
+			header: "2024-01-15 15:04:05 UTC",
+			want:   timeP(time.Date(2024, 1, 15, 15, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:   "RFC 3339 timestamp",
+			header: "2024-01-15T15:04:05Z",
+			want:   timeP(time.Date(2024, 1, 15, 15, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:   "unrecognized format",
+			header: "someday",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseExpirationHeader(tt.header)
+			if tt.want == nil {
+				require.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				require.True(t, tt.want.Equal(*got))
+			}
+		})
+	}
+}
+
+// The --json schema is a contract with consumers: always an object keyed by
+// hostname, never null or a bare empty struct, regardless of how many hosts
+// or accounts are configured.
+func Test_authStatusJSONShape(t *testing.T) {
+	marshal := func(t *testing.T, status *authStatus) string {
+		t.Helper()
+		raw, err := json.Marshal(status.ExportData([]string{"hosts"}))
+		require.NoError(t, err)
+		return string(raw)
+	}
+
+	t.Run("zero hosts", func(t *testing.T) {
+		require.Equal(t, `{"hosts":{}}`, marshal(t, newAuthStatus()))
+	})
+
+	t.Run("one host", func(t *testing.T) {
+		status := newAuthStatus()
+		status.Hosts["github.com"] = append(status.Hosts["github.com"], authEntry{
+			State: authEntryStateSuccess,
+			Host:  "github.com",
+			Login: "monalisa",
+		})
+
+		var unmarshaled authStatus
+		require.NoError(t, json.Unmarshal([]byte(marshal(t, status)), &unmarshaled))
+		require.Len(t, unmarshaled.Hosts, 1)
+		require.Len(t, unmarshaled.Hosts["github.com"], 1)
+	})
+
+	t.Run("multiple accounts across hosts", func(t *testing.T) {
+		status := newAuthStatus()
+		status.Hosts["github.com"] = append(status.Hosts["github.com"],
+			authEntry{State: authEntryStateSuccess, Host: "github.com", Login: "monalisa"},
+			authEntry{State: authEntryStateError, Host: "github.com", Login: "monalisa-2"},
+		)
+		status.Hosts["ghe.io"] = append(status.Hosts["ghe.io"],
+			authEntry{State: authEntryStateSuccess, Host: "ghe.io", Login: "monalisa-ghe"},
+		)
+
+		var unmarshaled authStatus
+		require.NoError(t, json.Unmarshal([]byte(marshal(t, status)), &unmarshaled))
+		require.Len(t, unmarshaled.Hosts, 2)
+		require.Len(t, unmarshaled.Hosts["github.com"], 2)
+		require.Len(t, unmarshaled.Hosts["ghe.io"], 1)
+	})
+}
+
+func Test_maskToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{
+			name:  "personal access token",
+			token: "ghp_abc123",
+			want:  "ghp_******",
+		},
+		{
+			name:  "oauth token",
+			token: "gho_abc123",
+			want:  "gho_******",
+		},
+		{
+			name:  "GitHub App installation token",
+			token: "ghs_abc123",
+			want:  "ghs_******",
+		},
+		{
+			name:  "fine-grained token masks past internal underscores",
+			token: "github_pat_11ABC_def456",
+			want:  "github_pat_************",
+		},
+		{
+			name:  "unknown prefix is fully masked",
+			token: "custom_secret",
+			want:  "*************",
+		},
+		{
+			name:  "no underscore is fully masked",
+			token: "hunter2",
+			want:  "*******",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, maskToken(tt.token))
+		})
+	}
+}
+
+func timeP(t time.Time) *time.Time {
+	return &t
+}
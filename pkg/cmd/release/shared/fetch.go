@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -136,25 +138,93 @@ type fetchResult struct {
 }
 
 func FetchRefSHA(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, tagName string) (string, error) {
-	path := fmt.Sprintf("repos/%s/%s/git/ref/tags/%s", repo.RepoOwner(), repo.RepoName(), tagName)
+	sha, err := fetchGitRefSHA(ctx, httpClient, repo, "tags/"+tagName)
+	if errors.Is(err, ErrRefNotFound) {
+		return "", ErrReleaseNotFound
+	}
+	return sha, err
+}
+
+var ErrRefNotFound = errors.New("ref not found")
+
+var commitSHARegexp = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// ResolveRef resolves a tag name, branch name, or full commit SHA to a commit
+// SHA. Tags take precedence over branches; a 40-character hexadecimal ref is
+// returned as-is when neither exists.
+func ResolveRef(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, ref string) (string, error) {
+	for _, refPath := range []string{"tags/" + ref, "heads/" + ref} {
+		sha, err := fetchGitRefSHA(ctx, httpClient, repo, refPath)
+		if errors.Is(err, ErrRefNotFound) {
+			continue
+		}
+		return sha, err
+	}
+
+	if commitSHARegexp.MatchString(ref) {
+		return ref, nil
+	}
+
+	return "", fmt.Errorf("no tag, branch, or commit SHA matches %q", ref)
+}
+
+// maxRefFetchRetries bounds how often a transient failure is retried before
+// giving up.
+const maxRefFetchRetries = 3
+
+// refFetchRetryInterval is the initial backoff interval between retries. It is
+// a variable so tests can avoid real waits.
+var refFetchRetryInterval = time.Second
+
+// fetchGitRefSHA retries transient server errors (5xx) and rate limiting (429)
+// with exponential backoff, honoring Retry-After when the server provides it.
+// A 404 is returned immediately as ErrRefNotFound.
+func fetchGitRefSHA(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, refPath string) (string, error) {
+	interval := refFetchRetryInterval
+	for attempt := 0; ; attempt++ {
+		sha, retryAfter, retryable, err := fetchGitRefSHAOnce(ctx, httpClient, repo, refPath)
+		if err == nil || !retryable || attempt >= maxRefFetchRetries {
+			return sha, err
+		}
+
+		wait := interval
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		interval *= 2
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func fetchGitRefSHAOnce(ctx context.Context, httpClient *http.Client, repo ghrepo.Interface, refPath string) (sha string, retryAfter time.Duration, retryable bool, err error) {
+	path := fmt.Sprintf("repos/%s/%s/git/ref/%s", repo.RepoOwner(), repo.RepoName(), refPath)
 	req, err := http.NewRequestWithContext(ctx, "GET", ghinstance.RESTPrefix(repo.RepoHost())+path, nil)
 	if err != nil {
-		return "", err
+		return "", 0, false, err
 	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return "", 0, false, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusNotFound {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
 		_, _ = io.Copy(io.Discard, resp.Body)
-		return "", ErrReleaseNotFound
-	}
-
-	if resp.StatusCode > 299 {
-		return "", api.HandleHTTPError(resp)
+		return "", 0, false, ErrRefNotFound
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		if seconds, parseErr := strconv.Atoi(resp.Header.Get("Retry-After")); parseErr == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		return "", retryAfter, true, api.HandleHTTPError(resp)
+	case resp.StatusCode > 299:
+		return "", 0, false, api.HandleHTTPError(resp)
 	}
 
 	var ref struct {
@@ -164,10 +234,10 @@ func FetchRefSHA(ctx context.Context, httpClient *http.Client, repo ghrepo.Inter
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&ref); err != nil {
-		return "", fmt.Errorf("failed to parse ref response: %w", err)
+		return "", 0, false, fmt.Errorf("failed to parse ref response: %w", err)
 	}
 
-	return ref.Object.SHA, nil
+	return ref.Object.SHA, 0, false, nil
 }
 
 // FetchRelease finds a published repository release by its tagName, or a draft release by its pending tag name.
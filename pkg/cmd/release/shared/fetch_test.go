@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 
@@ -14,6 +15,11 @@ import (
 )
 
 func TestFetchRefSHA(t *testing.T) {
+	t.Cleanup(func(interval time.Duration) func() {
+		refFetchRetryInterval = time.Millisecond
+		return func() { refFetchRetryInterval = interval }
+	}(refFetchRetryInterval))
+
 	tests := []struct {
 		name            string
 		tagName         string
@@ -63,13 +69,20 @@ func TestFetchRefSHA(t *testing.T) {
 
 			path := "repos/owner/repo/git/ref/tags/" + tt.tagName
 			if tt.responseStatus == 404 || tt.responseStatus == 500 {
-				fakeHTTP.Register(
-					httpmock.REST("GET", path),
-					httpmock.JSONErrorResponse(tt.responseStatus, api.HTTPError{
-						StatusCode: tt.responseStatus,
-						Message:    tt.responseMessage,
-					}),
-				)
+				// 500s are retried, so register a stub per attempt
+				stubs := 1
+				if tt.responseStatus == 500 {
+					stubs = maxRefFetchRetries + 1
+				}
+				for i := 0; i < stubs; i++ {
+					fakeHTTP.Register(
+						httpmock.REST("GET", path),
+						httpmock.JSONErrorResponse(tt.responseStatus, api.HTTPError{
+							StatusCode: tt.responseStatus,
+							Message:    tt.responseMessage,
+						}),
+					)
+				}
 			} else {
 				fakeHTTP.Register(
 					httpmock.REST("GET", path),
@@ -92,3 +105,168 @@ func TestFetchRefSHA(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveRef(t *testing.T) {
+	t.Cleanup(func(interval time.Duration) func() {
+		refFetchRetryInterval = time.Millisecond
+		return func() { refFetchRetryInterval = interval }
+	}(refFetchRetryInterval))
+
+	sha := "1234567890abcdef1234567890abcdef12345678"
+	refBody := `{"object": {"sha": "` + sha + `"}}`
+	notFound := httpmock.JSONErrorResponse(404, api.HTTPError{StatusCode: 404, Message: "Not found"})
+
+	tests := []struct {
+		name         string
+		ref          string
+		httpStubs    func(*httpmock.Registry)
+		expectedSHA  string
+		errorMessage string
+	}{
+		{
+			name: "resolves a tag",
+			ref:  "v1.2.3",
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"),
+					httpmock.StatusStringResponse(200, refBody))
+			},
+			expectedSHA: sha,
+		},
+		{
+			name: "falls back to a branch",
+			ref:  "trunk",
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/tags/trunk"), notFound)
+				reg.Register(
+					httpmock.REST("GET", "repos/owner/repo/git/ref/heads/trunk"),
+					httpmock.StatusStringResponse(200, refBody))
+			},
+			expectedSHA: sha,
+		},
+		{
+			name: "falls back to a literal SHA",
+			ref:  sha,
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/tags/"+sha), notFound)
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/heads/"+sha), notFound)
+			},
+			expectedSHA: sha,
+		},
+		{
+			name: "no match",
+			ref:  "mystery",
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/tags/mystery"), notFound)
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/heads/mystery"), notFound)
+			},
+			errorMessage: `no tag, branch, or commit SHA matches "mystery"`,
+		},
+		{
+			name: "server error",
+			ref:  "v1.2.3",
+			httpStubs: func(reg *httpmock.Registry) {
+				// 500s are retried, so register a stub per attempt
+				for i := 0; i <= maxRefFetchRetries; i++ {
+					reg.Register(
+						httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"),
+						httpmock.JSONErrorResponse(500, api.HTTPError{StatusCode: 500, Message: "arbitrary error"}))
+				}
+			},
+			errorMessage: "HTTP 500: arbitrary error (https://api.github.com/repos/owner/repo/git/ref/tags/v1.2.3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHTTP := &httpmock.Registry{}
+			defer fakeHTTP.Verify(t)
+			tt.httpStubs(fakeHTTP)
+
+			repo, err := ghrepo.FromFullName("owner/repo")
+			require.NoError(t, err)
+
+			httpClient := &http.Client{Transport: fakeHTTP}
+			sha, err := ResolveRef(context.Background(), httpClient, repo, tt.ref)
+
+			if tt.errorMessage != "" {
+				assert.Contains(t, err.Error(), tt.errorMessage)
+				assert.Empty(t, sha)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedSHA, sha)
+			}
+		})
+	}
+}
+
+func TestFetchRefSHARetriesTransientErrors(t *testing.T) {
+	t.Cleanup(func(interval time.Duration) func() {
+		refFetchRetryInterval = time.Millisecond
+		return func() { refFetchRetryInterval = interval }
+	}(refFetchRetryInterval))
+
+	sha := "1234567890abcdef1234567890abcdef12345678"
+	serverError := httpmock.JSONErrorResponse(500, api.HTTPError{StatusCode: 500, Message: "arbitrary error"})
+
+	tests := []struct {
+		name         string
+		httpStubs    func(*httpmock.Registry)
+		expectedSHA  string
+		errorMessage string
+	}{
+		{
+			name: "500 then 200",
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"), serverError)
+				reg.Register(
+					httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"),
+					httpmock.StatusStringResponse(200, `{"object": {"sha": "`+sha+`"}}`))
+			},
+			expectedSHA: sha,
+		},
+		{
+			name: "429 with Retry-After then 200",
+			httpStubs: func(reg *httpmock.Registry) {
+				reg.Register(
+					httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"),
+					httpmock.WithHeader(httpmock.StatusStringResponse(429, "{}"), "Retry-After", "0"))
+				reg.Register(
+					httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"),
+					httpmock.StatusStringResponse(200, `{"object": {"sha": "`+sha+`"}}`))
+			},
+			expectedSHA: sha,
+		},
+		{
+			name: "persistent 500 gives up",
+			httpStubs: func(reg *httpmock.Registry) {
+				for i := 0; i <= maxRefFetchRetries; i++ {
+					reg.Register(httpmock.REST("GET", "repos/owner/repo/git/ref/tags/v1.2.3"), serverError)
+				}
+			},
+			errorMessage: "HTTP 500: arbitrary error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeHTTP := &httpmock.Registry{}
+			defer fakeHTTP.Verify(t)
+			tt.httpStubs(fakeHTTP)
+
+			repo, err := ghrepo.FromFullName("owner/repo")
+			require.NoError(t, err)
+
+			httpClient := &http.Client{Transport: fakeHTTP}
+			sha, err := FetchRefSHA(context.Background(), httpClient, repo, "v1.2.3")
+
+			if tt.errorMessage != "" {
+				assert.Contains(t, err.Error(), tt.errorMessage)
+				assert.Empty(t, sha)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedSHA, sha)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package search
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -15,59 +18,95 @@ const (
 	KindCommits      = "commits"
 )
 
+// Values GitHub accepts for the Is qualifier in issue searches. Assigning
+// these instead of raw strings avoids typos that would otherwise render a
+// silently no-op query; Validate rejects anything outside this set.
+const (
+	IsBlocked  = "blocked"
+	IsBlocking = "blocking"
+	IsClosed   = "closed"
+	IsDraft    = "draft"
+	IsIssue    = "issue"
+	IsLocked   = "locked"
+	IsMerged   = "merged"
+	IsOpen     = "open"
+	IsPR       = "pr"
+	IsPrivate  = "private"
+	IsPublic   = "public"
+	IsQueued   = "queued"
+	IsUnlocked = "unlocked"
+	IsUnmerged = "unmerged"
+)
+
+// Values GitHub accepts for the Review qualifier in issue searches; Validate
+// rejects anything outside this set. ReviewRequested and ReviewedBy are not
+// enumerated: they accept user logins and team slugs.
+const (
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+	ReviewNone             = "none"
+	ReviewRequired         = "required"
+)
+
 type Query struct {
-	Keywords   []string
-	Kind       string
-	Limit      int
-	Order      string
-	Page       int
+	Keywords []string
+	Kind     string
+	Limit    int
+	Order    string
+	Page     int
+	// Qualifiers the results must match.
 	Qualifiers Qualifiers
-	Sort       string
+	// NotQualifiers the results must not match; they render as -key:value.
+	NotQualifiers Qualifiers
+	Sort          string
 }
 
 type Qualifiers struct {
-	Archived            *bool
-	Assignee            string
-	Author              string
-	AuthorDate          string
-	AuthorEmail         string
-	AuthorName          string
-	Base                string
-	Closed              string
-	Commenter           string
-	Comments            string
-	Committer           string
-	CommitterDate       string
-	CommitterEmail      string
-	CommitterName       string
-	Created             string
-	Draft               *bool
-	Extension           string
-	Filename            string
-	Followers           string
-	Fork                string
-	Forks               string
-	GoodFirstIssues     string
-	Hash                string
-	Head                string
-	HelpWantedIssues    string
-	In                  []string
-	Interactions        string
-	Involves            string
-	Is                  []string
-	Label               []string
-	Language            string
-	License             []string
-	Mentions            string
-	Merge               *bool
-	Merged              string
-	Milestone           string
-	No                  []string
-	Parent              string
-	Path                string
-	Project             string
-	Pushed              string
-	Reactions           string
+	Archived         *bool
+	Assignee         string
+	Author           string
+	AuthorDate       string
+	AuthorEmail      string
+	AuthorName       string
+	Base             string
+	Closed           string
+	Commenter        string
+	Comments         string
+	Committer        string
+	CommitterDate    string
+	CommitterEmail   string
+	CommitterName    string
+	Created          string
+	Draft            *bool
+	Extension        string
+	Filename         string
+	Followers        string
+	Fork             string
+	Forks            string
+	GoodFirstIssues  string
+	Hash             string
+	Head             string
+	HelpWantedIssues string
+	In               []string
+	Interactions     string
+	Involves         string
+	Is               []string
+	Label            []string
+	Language         string
+	License          []string
+	Mentions         string
+	Merge            *bool
+	Merged           string
+	Milestone        string
+	No               []string
+	Parent           string
+	Path             string
+	Project          string
+	Pushed           string
+	Reactions        string
+	// Repo holds full repository names. A value of the form "org/*" matches
+	// every repository owned by org; it renders as user:org, which is how
+	// both search backends express an owner-wide filter.
 	Repo                []string
 	Review              string
 	ReviewRequested     string
@@ -105,9 +144,73 @@ func (q Query) StandardSearchString() string {
 	qualifiers := formatQualifiers(q.Qualifiers, nil)
 	keywords := formatKeywords(q.Keywords)
 	all := append(keywords, qualifiers...)
+	all = append(all, formatNegatedQualifiers(q.NotQualifiers)...)
 	return strings.TrimSpace(strings.Join(all, " "))
 }
 
+// String returns the fully self-contained representation of the query,
+// including the Sort and Order fields rendered with the documented
+// sort:<field>-<order> syntax. Use it for contexts that encode sorting inside
+// the query string itself (e.g. GUI search boxes); the REST searchers instead
+// pass sort and order as separate URL parameters next to
+// StandardSearchString.
+func (q Query) String() string {
+	s := q.StandardSearchString()
+	sort := q.formatSort()
+	switch {
+	case sort == "":
+		return s
+	case s == "":
+		return sort
+	}
+	return s + " " + sort
+}
+
+// URL returns the link to run the query in the web GUI on host. Most kinds
+// map to the global search page, e.g.
+// https://<host>/search?q=<encoded>&type=<kind>, with Sort and Order passed
+// as separate parameters. Issue queries instead link to the issues dashboard
+// (https://<host>/issues), which supports the advanced issue search syntax;
+// there sorting is encoded inside q with the sort:<field>-<order> qualifier.
+func (q Query) URL(host string) string {
+	qs := url.Values{}
+	if q.Kind == KindIssues {
+		s := q.AdvancedIssueSearchString()
+		if sort := q.formatSort(); sort != "" {
+			if s != "" {
+				s += " "
+			}
+			s += sort
+		}
+		qs.Set("q", s)
+		return fmt.Sprintf("https://%s/issues?%s", host, qs.Encode())
+	}
+	if q.Kind != "" {
+		qs.Set("type", q.Kind)
+	}
+	qs.Set("q", q.StandardSearchString())
+	if q.Order != "" {
+		qs.Set(orderKey, q.Order)
+	}
+	if q.Sort != "" {
+		qs.Set(sortKey, q.Sort)
+	}
+	return fmt.Sprintf("https://%s/search?%s", host, qs.Encode())
+}
+
+// formatSort renders Sort and Order as an in-query qualifier. The syntax
+// requires a field, so Order alone renders nothing; Sort alone renders
+// sort:<field>, leaving the direction to the server default.
+func (q Query) formatSort() string {
+	if q.Sort == "" {
+		return ""
+	}
+	if q.Order == "" {
+		return "sort:" + q.Sort
+	}
+	return fmt.Sprintf("sort:%s-%s", q.Sort, q.Order)
+}
+
 // AdvancedIssueSearchString returns the string representation of the query
 // compatible with the advanced issue search syntax. The query can be used in
 // Issues tab (of repositories) and the Issues dashboard (i.e.
@@ -127,15 +230,58 @@ func (q Query) AdvancedIssueSearchString() string {
 	qualifiers := formatQualifiers(q.Qualifiers, formatAdvancedIssueSearch)
 	keywords := formatKeywords(q.Keywords)
 	all := append(keywords, qualifiers...)
+	all = append(all, formatNegatedQualifiers(q.NotQualifiers)...)
 	return strings.TrimSpace(strings.Join(all, " "))
 }
 
+// StandardSearchStringPreservingOrder is like StandardSearchString but keeps
+// multi-value qualifiers in the order their values were added instead of
+// sorting them. Qualifiers render in the struct's field declaration order,
+// which is stable across runs, so the result is suitable for GUI deep-links
+// and as a cache key that mirrors how the query was built.
+func (q Query) StandardSearchStringPreservingOrder() string {
+	keywords := formatKeywords(q.Keywords)
+	all := append(keywords, formatQualifiersPreservingOrder(q.Qualifiers, "")...)
+	all = append(all, formatQualifiersPreservingOrder(q.NotQualifiers, "-")...)
+	return strings.TrimSpace(strings.Join(all, " "))
+}
+
+// formatQualifiersPreservingOrder renders qualifiers in field declaration
+// order, keeping multi-value qualifiers in the order their values were set.
+// The prefix is prepended to every rendered qualifier (e.g. "-" to negate).
+func formatQualifiersPreservingOrder(qs Qualifiers, prefix string) []string {
+	qs = qs.expandRepoWildcards()
+	m := qs.Map()
+	t := reflect.TypeOf(qs)
+	var result []string
+	for i := 0; i < t.NumField(); i++ {
+		key := camelToKebab(t.Field(i).Name)
+		for _, v := range m[key] {
+			result = append(result, fmt.Sprintf("%s%s:%s", prefix, key, quote(v)))
+		}
+	}
+	return result
+}
+
+// formatNegatedQualifiers renders qualifiers as exclusions, e.g. -label:bug.
+// Negated qualifiers are AND-ed by both search backends, so the OR-grouping
+// applied to special qualifiers by the advanced issue syntax does not apply
+// here: excluding either of two values means excluding both.
+func formatNegatedQualifiers(qs Qualifiers) []string {
+	formatted := formatQualifiers(qs, nil)
+	result := make([]string, len(formatted))
+	for i, f := range formatted {
+		result[i] = "-" + f
+	}
+	return result
+}
+
 func formatAdvancedIssueSearch(qualifier string, vs []string) (s []string, applicable bool) {
 	switch qualifier {
 	case "in":
 		return formatSpecialQualifiers("in", vs, [][]string{{"title", "body", "comments"}}), true
 	case "is":
-		return formatSpecialQualifiers("is", vs, [][]string{{"blocked", "blocking"}, {"closed", "open"}, {"issue", "pr"}, {"locked", "unlocked"}, {"merged", "unmerged"}, {"private", "public"}}), true
+		return formatSpecialQualifiers("is", vs, [][]string{{IsBlocked, IsBlocking}, {IsClosed, IsOpen}, {IsIssue, IsPR}, {IsLocked, IsUnlocked}, {IsMerged, IsUnmerged}, {IsPrivate, IsPublic}}), true
 	case "user", "repo":
 		return []string{groupWithOR(qualifier, vs)}, true
 	}
@@ -200,6 +346,478 @@ func groupWithOR(qualifier string, vs []string) string {
 	return fmt.Sprintf("(%s)", strings.Join(all, " OR "))
 }
 
+// Describe returns a human-readable description of the query, suitable for
+// confirmation prompts and logs, e.g. "open issues in cli/cli labeled 'bug'
+// created after 2023-01-01". Common qualifiers are mapped to readable
+// phrases; the rest fall back to "key: value" phrasing.
+func (q Query) Describe() string {
+	// Qualifiers described with a dedicated phrase below. Anything else is
+	// described with the generic "key: value" fallback.
+	described := map[string]struct{}{
+		"repo": {}, "label": {}, "author": {}, "assignee": {},
+		"state": {}, "created": {}, "updated": {}, "language": {},
+	}
+
+	var parts []string
+
+	subject := q.Kind
+	if subject == "" {
+		subject = "results"
+	}
+	if q.Qualifiers.State != "" {
+		subject = fmt.Sprintf("%s %s", q.Qualifiers.State, subject)
+	}
+	parts = append(parts, subject)
+
+	if len(q.Keywords) > 0 {
+		parts = append(parts, fmt.Sprintf("matching %q", strings.Join(q.Keywords, " ")))
+	}
+	if len(q.Qualifiers.Repo) > 0 {
+		parts = append(parts, fmt.Sprintf("in %s", strings.Join(q.Qualifiers.Repo, ", ")))
+	}
+	if q.Qualifiers.Language != "" {
+		parts = append(parts, fmt.Sprintf("written in %s", q.Qualifiers.Language))
+	}
+	if len(q.Qualifiers.Label) > 0 {
+		labels := make([]string, 0, len(q.Qualifiers.Label))
+		for _, l := range q.Qualifiers.Label {
+			labels = append(labels, fmt.Sprintf("'%s'", l))
+		}
+		parts = append(parts, fmt.Sprintf("labeled %s", strings.Join(labels, ", ")))
+	}
+	if q.Qualifiers.Author != "" {
+		parts = append(parts, fmt.Sprintf("authored by %s", q.Qualifiers.Author))
+	}
+	if q.Qualifiers.Assignee != "" {
+		parts = append(parts, fmt.Sprintf("assigned to %s", q.Qualifiers.Assignee))
+	}
+	if q.Qualifiers.Created != "" {
+		parts = append(parts, fmt.Sprintf("created %s", describeDateRange(q.Qualifiers.Created)))
+	}
+	if q.Qualifiers.Updated != "" {
+		parts = append(parts, fmt.Sprintf("updated %s", describeDateRange(q.Qualifiers.Updated)))
+	}
+
+	// Fall back to "key: value" phrasing for the remaining qualifiers, in
+	// deterministic (sorted) order.
+	m := q.Qualifiers.Map()
+	rest := make([]string, 0, len(m))
+	for k := range m {
+		if _, ok := described[k]; ok {
+			continue
+		}
+		rest = append(rest, k)
+	}
+	slices.Sort(rest)
+	for _, k := range rest {
+		parts = append(parts, fmt.Sprintf("%s: %s", k, strings.Join(m[k], ", ")))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// describeDateRange converts a date-range qualifier value (e.g. ">2023-01-01"
+// or "2023-01-01..2023-12-31") to a readable phrase.
+func describeDateRange(v string) string {
+	switch {
+	case strings.HasPrefix(v, ">="):
+		return fmt.Sprintf("on or after %s", v[2:])
+	case strings.HasPrefix(v, "<="):
+		return fmt.Sprintf("on or before %s", v[2:])
+	case strings.HasPrefix(v, ">"):
+		return fmt.Sprintf("after %s", v[1:])
+	case strings.HasPrefix(v, "<"):
+		return fmt.Sprintf("before %s", v[1:])
+	}
+	if from, to, found := strings.Cut(v, ".."); found {
+		if to == "*" || to == "" {
+			return fmt.Sprintf("on or after %s", from)
+		}
+		if from == "*" || from == "" {
+			return fmt.Sprintf("on or before %s", to)
+		}
+		return fmt.Sprintf("between %s and %s", from, to)
+	}
+	return fmt.Sprintf("on %s", v)
+}
+
+// allowedQualifierValues holds the values GitHub accepts for a handful of
+// enumerated qualifiers, per search kind. Qualifiers or kinds absent from
+// these maps are not validated.
+var allowedQualifierValues = map[string]map[string][]string{
+	"in": {
+		KindRepositories: {"description", "name", "readme", "topics"},
+		KindCode:         {"file", "path"},
+		KindIssues:       {"body", "comments", "title"},
+	},
+	"is": {
+		KindRepositories: {"archived", "fork", "internal", "mirror", IsPrivate, IsPublic, "sponsorable", "template"},
+		KindIssues:       {IsBlocked, IsBlocking, IsClosed, IsDraft, IsIssue, IsLocked, IsMerged, IsOpen, IsPR, IsPrivate, IsPublic, IsQueued, IsUnlocked, IsUnmerged},
+	},
+	"state": {
+		KindIssues: {"closed", "open"},
+	},
+	"review": {
+		KindIssues: {ReviewApproved, ReviewChangesRequested, ReviewNone, ReviewRequired},
+	},
+	"type": {
+		KindIssues: {"issue", "pr"},
+	},
+}
+
+// Validate checks the In, Is, State, Review, and Type qualifiers against the
+// values GitHub accepts for the query's kind, returning a descriptive error
+// for the first unknown value. Validation is optional: StandardSearchString
+// and AdvancedIssueSearchString render whatever values are set.
+func (q Query) Validate() error {
+	checks := []struct {
+		qualifier string
+		values    []string
+	}{
+		{"in", q.Qualifiers.In},
+		{"is", q.Qualifiers.Is},
+		{"state", []string{q.Qualifiers.State}},
+		{"review", []string{q.Qualifiers.Review}},
+		{"type", []string{q.Qualifiers.Type}},
+	}
+
+	for _, c := range checks {
+		allowed, ok := allowedQualifierValues[c.qualifier][q.Kind]
+		if !ok {
+			continue
+		}
+		for _, v := range c.values {
+			if v == "" {
+				continue
+			}
+			if !slices.Contains(allowed, v) {
+				return fmt.Errorf("invalid value %q for %q qualifier in %s search (must be one of: %s)",
+					v, c.qualifier, q.Kind, strings.Join(allowed, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether an item, described by its attributes, satisfies the
+// query's qualifiers locally, without an API round trip. Only scalar string
+// qualifiers (e.g. state, author, milestone) and boolean qualifiers (e.g.
+// archived, draft) are evaluated; multi-value qualifiers and range
+// expressions (values containing "..", "<", ">", or "*") are ignored.
+// Attribute keys use the same kebab-case form as the rendered query, and
+// string comparisons are case-insensitive.
+func (q Query) Matches(attrs map[string]string) bool {
+	v := reflect.ValueOf(q.Qualifiers)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := v.Field(i)
+		key := camelToKebab(t.Field(i).Name)
+		switch f.Kind() {
+		case reflect.String:
+			want := f.String()
+			if want == "" {
+				continue
+			}
+			if strings.ContainsAny(want, "<>*") || strings.Contains(want, "..") {
+				// Range expressions are not evaluated locally.
+				continue
+			}
+			if !strings.EqualFold(attrs[key], want) {
+				return false
+			}
+		case reflect.Ptr:
+			if f.IsNil() {
+				continue
+			}
+			if attrs[key] != fmt.Sprintf("%v", f.Elem()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// DateRange returns a date-range qualifier value matching dates between from
+// and to inclusive, e.g. "2023-01-01..2023-12-31", for assignment to date
+// qualifiers such as Created, Updated, Merged, or Closed. A zero from or to
+// makes the range open-ended on that side.
+func DateRange(from, to time.Time) string {
+	switch {
+	case from.IsZero() && to.IsZero():
+		return ""
+	case from.IsZero():
+		return OnOrBefore(to)
+	case to.IsZero():
+		return OnOrAfter(from)
+	}
+	return fmt.Sprintf("%s..%s", formatDate(from), formatDate(to))
+}
+
+// After returns a qualifier value matching dates strictly after t.
+func After(t time.Time) string {
+	return ">" + formatDate(t)
+}
+
+// Before returns a qualifier value matching dates strictly before t.
+func Before(t time.Time) string {
+	return "<" + formatDate(t)
+}
+
+// OnOrAfter returns a qualifier value matching dates on or after t.
+func OnOrAfter(t time.Time) string {
+	return ">=" + formatDate(t)
+}
+
+// OnOrBefore returns a qualifier value matching dates on or before t.
+func OnOrBefore(t time.Time) string {
+	return "<=" + formatDate(t)
+}
+
+// ParseDateRange parses a date-range qualifier value — an exact date, a
+// relational expression such as ">2024-01-01" or "<=2024-06-01", or an
+// inclusive range such as "2024-01-01..2024-02-01" (with "*" for an open
+// end) — and returns a predicate reporting whether a time falls within it.
+// Bounds accept the date-only and RFC3339 forms produced by formatDate.
+func ParseDateRange(value string) (func(time.Time) bool, error) {
+	if from, to, found := strings.Cut(value, ".."); found {
+		var fromStart, toEnd time.Time
+		if from != "*" {
+			start, _, err := parseDateBound(from)
+			if err != nil {
+				return nil, err
+			}
+			fromStart = start
+		}
+		if to != "*" {
+			_, end, err := parseDateBound(to)
+			if err != nil {
+				return nil, err
+			}
+			toEnd = end
+		}
+		return func(t time.Time) bool {
+			return (fromStart.IsZero() || !t.Before(fromStart)) && (toEnd.IsZero() || t.Before(toEnd))
+		}, nil
+	}
+	switch {
+	case strings.HasPrefix(value, ">="):
+		start, _, err := parseDateBound(value[2:])
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool { return !t.Before(start) }, nil
+	case strings.HasPrefix(value, "<="):
+		_, end, err := parseDateBound(value[2:])
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool { return t.Before(end) }, nil
+	case strings.HasPrefix(value, ">"):
+		_, end, err := parseDateBound(value[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool { return !t.Before(end) }, nil
+	case strings.HasPrefix(value, "<"):
+		start, _, err := parseDateBound(value[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) bool { return t.Before(start) }, nil
+	}
+	start, end, err := parseDateBound(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(t time.Time) bool { return !t.Before(start) && t.Before(end) }, nil
+}
+
+// parseDateBound parses a single date or timestamp and returns the half-open
+// interval it covers: a date spans its whole day, a timestamp a single second.
+func parseDateBound(s string) (start, end time.Time, err error) {
+	if d, perr := time.Parse(time.DateOnly, s); perr == nil {
+		return d, d.AddDate(0, 0, 1), nil
+	}
+	if d, perr := time.Parse(time.RFC3339, s); perr == nil {
+		return d, d.Add(time.Second), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD or RFC3339)", s)
+}
+
+// formatDate renders t for use in a date qualifier. Dates at midnight are
+// rendered date-only; anything with a time component uses the full RFC3339
+// form accepted by the search API.
+func formatDate(t time.Time) string {
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// ParseQuery parses a raw search string, such as one produced by
+// StandardSearchString, back into a structured Query. Tokens of the form
+// key:value whose key matches a known qualifier are mapped onto Qualifiers;
+// anything else is collected into Keywords. Quoted values produced by quote
+// are unquoted.
+func ParseQuery(s string) (Query, error) {
+	var q Query
+
+	tokens, err := tokenizeQuery(s)
+	if err != nil {
+		return q, err
+	}
+
+	for _, token := range tokens {
+		key, value, found := strings.Cut(token, ":")
+		if !found || key == "" || strings.Contains(key, `"`) {
+			q.Keywords = append(q.Keywords, unquoteToken(token))
+			continue
+		}
+		if !setQualifier(&q.Qualifiers, key, unquoteToken(value)) {
+			q.Keywords = append(q.Keywords, fmt.Sprintf("%s:%s", key, unquoteToken(value)))
+		}
+	}
+
+	return q, nil
+}
+
+// tokenizeQuery splits a query string on whitespace, keeping quoted segments
+// intact.
+func tokenizeQuery(s string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in query: %s", s)
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// unquoteToken undoes the quoting applied by quote, returning s unchanged
+// when it is not quoted.
+func unquoteToken(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	return s
+}
+
+// setQualifier assigns value to the Qualifiers field whose kebab-case name
+// matches key, reporting whether such a field exists. Slice fields are
+// appended to so repeated qualifiers accumulate.
+func setQualifier(qs *Qualifiers, key, value string) bool {
+	v := reflect.ValueOf(qs).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if camelToKebab(t.Field(i).Name) != key {
+			continue
+		}
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Slice:
+			f.Set(reflect.Append(f, reflect.ValueOf(value)))
+		case reflect.Ptr:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return false
+			}
+			f.Set(reflect.ValueOf(&b))
+		default:
+			f.SetString(value)
+		}
+		return true
+	}
+	return false
+}
+
+// Clone returns a deep copy of the qualifiers. Slice and pointer fields are
+// copied, so mutating the clone's Repo, Label, etc. does not affect the
+// original; this makes deriving query variants from a shared base safe.
+func (q Qualifiers) Clone() Qualifiers {
+	v := reflect.ValueOf(&q).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Kind() {
+		case reflect.Slice:
+			if f.IsNil() {
+				continue
+			}
+			c := reflect.MakeSlice(f.Type(), f.Len(), f.Len())
+			reflect.Copy(c, f)
+			f.Set(c)
+		case reflect.Ptr:
+			if f.IsNil() {
+				continue
+			}
+			p := reflect.New(f.Type().Elem())
+			p.Elem().Set(f.Elem())
+			f.Set(p)
+		}
+	}
+	return q
+}
+
+// MergeWith returns a copy of the qualifiers combined with other: slice
+// fields are unioned with duplicates removed (keeping first-seen order), and
+// scalar and pointer fields are overwritten by the corresponding non-zero
+// fields of other. Neither receiver nor argument is modified. (Named
+// MergeWith because Merge is already taken by the merge commit qualifier.)
+func (q Qualifiers) MergeWith(other Qualifiers) Qualifiers {
+	merged := q.Clone()
+	dst := reflect.ValueOf(&merged).Elem()
+	src := reflect.ValueOf(other)
+	for i := 0; i < dst.NumField(); i++ {
+		d := dst.Field(i)
+		s := src.Field(i)
+		switch d.Kind() {
+		case reflect.Slice:
+			for j := 0; j < s.Len(); j++ {
+				var exists bool
+				for k := 0; k < d.Len(); k++ {
+					if d.Index(k).Equal(s.Index(j)) {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					d.Set(reflect.Append(d, s.Index(j)))
+				}
+			}
+		case reflect.Ptr:
+			if !s.IsNil() {
+				p := reflect.New(s.Type().Elem())
+				p.Elem().Set(s.Elem())
+				d.Set(p)
+			}
+		default:
+			if !s.IsZero() {
+				d.Set(s)
+			}
+		}
+	}
+	return merged
+}
+
 func (q Qualifiers) Map() map[string][]string {
 	m := map[string][]string{}
 	v := reflect.ValueOf(q)
@@ -238,6 +856,28 @@ func (q Qualifiers) Map() map[string][]string {
 	return m
 }
 
+// expandRepoWildcards returns a copy of the qualifiers in which Repo values
+// of the form "org/*" are moved to the User qualifier, deduplicated against
+// any owners already present. Explicit repositories and wildcard owners
+// compose: both backends treat repo: and user: as implicitly (legacy) or
+// explicitly (advanced) OR-ed, so repo:cli/cli alongside org/* matches
+// cli/cli plus every repository owned by org.
+func (q Qualifiers) expandRepoWildcards() Qualifiers {
+	expanded := q.Clone()
+	var repos []string
+	for _, r := range expanded.Repo {
+		if owner, ok := strings.CutSuffix(r, "/*"); ok && owner != "" && !strings.Contains(owner, "/") {
+			if !slices.Contains(expanded.User, owner) {
+				expanded.User = append(expanded.User, owner)
+			}
+			continue
+		}
+		repos = append(repos, r)
+	}
+	expanded.Repo = repos
+	return expanded
+}
+
 func quote(s string) string {
 	if strings.ContainsAny(s, " \"\t\r\n") {
 		return fmt.Sprintf("%q", s)
@@ -257,7 +897,7 @@ func formatQualifiers(qs Qualifiers, formatter func(qualifier string, vs []strin
 	}
 
 	var all []entry
-	for k, vs := range qs.Map() {
+	for k, vs := range qs.expandRepoWildcards().Map() {
 		if len(vs) == 0 {
 			continue
 		}
@@ -295,6 +935,13 @@ func formatQualifiers(qs Qualifiers, formatter func(qualifier string, vs []strin
 func formatKeywords(ks []string) []string {
 	result := make([]string, len(ks))
 	for i, k := range ks {
+		if strings.HasPrefix(k, "-") {
+			// A leading hyphen would be parsed as a negation, so quote the
+			// whole keyword to keep it a literal term. Callers intending to
+			// negate should use NotQualifiers instead.
+			result[i] = fmt.Sprintf("%q", k)
+			continue
+		}
 		before, after, found := strings.Cut(k, ":")
 		if !found {
 			result[i] = quote(k)
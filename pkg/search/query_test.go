@@ -2,8 +2,10 @@ package search
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var trueBool = true
@@ -61,6 +63,13 @@ func TestStandardSearchString(t *testing.T) {
 			},
 			out: `quote:keywords quote:"multiword keywords"`,
 		},
+		{
+			name: "quotes keywords with a leading hyphen",
+			query: Query{
+				Keywords: []string{"-foo", "bar", "-quote:keywords"},
+			},
+			out: `"-foo" bar "-quote:keywords"`,
+		},
 		{
 			name: "quotes qualifiers",
 			query: Query{
@@ -70,6 +79,26 @@ func TestStandardSearchString(t *testing.T) {
 			},
 			out: `topic:"quote qualifier"`,
 		},
+		{
+			name: "expands org wildcard repos to user qualifiers",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Repo: []string{"cli/cli", "acme/*"},
+				},
+			},
+			out: "keyword repo:cli/cli user:acme",
+		},
+		{
+			name: "org wildcard repos dedupe against existing user qualifiers",
+			query: Query{
+				Qualifiers: Qualifiers{
+					Repo: []string{"acme/*"},
+					User: []string{"acme"},
+				},
+			},
+			out: "user:acme",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -78,6 +107,143 @@ func TestStandardSearchString(t *testing.T) {
 	}
 }
 
+func TestQueryString(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		out   string
+	}{
+		{
+			name: "sort only renders sort:<field>",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Sort:     "created",
+			},
+			out: "keyword sort:created",
+		},
+		{
+			name: "order only renders nothing extra",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Order:    "desc",
+			},
+			out: "keyword",
+		},
+		{
+			name: "sort and order render sort:<field>-<order>",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					State: "open",
+				},
+				Sort:  "created",
+				Order: "desc",
+			},
+			out: "keyword state:open sort:created-desc",
+		},
+		{
+			name: "sort alone on an otherwise empty query",
+			query: Query{
+				Sort:  "stars",
+				Order: "asc",
+			},
+			out: "sort:stars-asc",
+		},
+		{
+			name:  "empty query renders empty string",
+			query: Query{},
+			out:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, tt.query.String())
+		})
+	}
+}
+
+func TestQueryURL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		host  string
+		url   string
+	}{
+		{
+			name: "repositories",
+			query: Query{
+				Kind:     KindRepositories,
+				Keywords: []string{"cli"},
+				Qualifiers: Qualifiers{
+					Language: "go",
+				},
+			},
+			host: "github.com",
+			url:  "https://github.com/search?q=cli+language%3Ago&type=repositories",
+		},
+		{
+			name: "code",
+			query: Query{
+				Kind:     KindCode,
+				Keywords: []string{"error"},
+				Qualifiers: Qualifiers{
+					Repo: []string{"cli/cli"},
+				},
+			},
+			host: "github.com",
+			url:  "https://github.com/search?q=error+repo%3Acli%2Fcli&type=code",
+		},
+		{
+			name: "commits with sort and order",
+			query: Query{
+				Kind:     KindCommits,
+				Keywords: []string{"fix"},
+				Sort:     "committer-date",
+				Order:    "desc",
+			},
+			host: "github.com",
+			url:  "https://github.com/search?order=desc&q=fix&sort=committer-date&type=commits",
+		},
+		{
+			name: "issues link to the dashboard with advanced syntax",
+			query: Query{
+				Kind:     KindIssues,
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Repo: []string{"foo/bar", "foo/baz"},
+				},
+				Sort:  "created",
+				Order: "asc",
+			},
+			host: "github.com",
+			url:  "https://github.com/issues?q=keyword+%28repo%3Afoo%2Fbar+OR+repo%3Afoo%2Fbaz%29+sort%3Acreated-asc",
+		},
+		{
+			name: "quoted values are encoded",
+			query: Query{
+				Kind:     KindRepositories,
+				Keywords: []string{"keyword with whitespace"},
+			},
+			host: "github.com",
+			url:  "https://github.com/search?q=%22keyword+with+whitespace%22&type=repositories",
+		},
+		{
+			name: "enterprise host",
+			query: Query{
+				Kind:     KindRepositories,
+				Keywords: []string{"cli"},
+			},
+			host: "ghe.io",
+			url:  "https://ghe.io/search?q=cli&type=repositories",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.url, tt.query.URL(tt.host))
+		})
+	}
+}
+
 func TestAdvancedIssueSearchString(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -143,6 +309,16 @@ func TestAdvancedIssueSearchString(t *testing.T) {
 			},
 			out: `keyword (in:body OR in:comments OR in:title) in:foo (is:blocked OR is:blocking) (is:closed OR is:open) (is:issue OR is:pr) (is:locked OR is:unlocked) (is:merged OR is:unmerged) (is:private OR is:public) is:foo (repo:foo/bar OR repo:foo/baz) (user:janedoe OR user:johndoe)`,
 		},
+		{
+			name: "Is constants group with OR",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Is: []string{IsPrivate, IsPublic},
+				},
+			},
+			out: `keyword (is:private OR is:public)`,
+		},
 		{
 			// Since this is a general purpose package, we can't assume with know all
 			// use cases of special qualifiers. So, here we ensure unknown values are
@@ -172,6 +348,17 @@ func TestAdvancedIssueSearchString(t *testing.T) {
 			},
 			out: `keyword in:bar in:foo is:bar is:foo label:bar label:foo license:bar license:foo no:bar no:foo topic:bar topic:foo`,
 		},
+		{
+			name: "org wildcard repos join the user OR group",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Repo: []string{"foo/bar", "acme/*"},
+					User: []string{"johndoe"},
+				},
+			},
+			out: `keyword repo:foo/bar (user:acme OR user:johndoe)`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -258,6 +445,61 @@ func TestQualifiersMap(t *testing.T) {
 	}
 }
 
+func TestQualifiersClone(t *testing.T) {
+	trueBool := true
+	original := Qualifiers{
+		Archived: &trueBool,
+		Label:    []string{"bug"},
+		Repo:     []string{"cli/cli"},
+		State:    "open",
+	}
+
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	clone.Label[0] = "enhancement"
+	clone.Repo = append(clone.Repo, "cli/go-gh")
+	*clone.Archived = false
+	clone.State = "closed"
+
+	assert.Equal(t, []string{"bug"}, original.Label)
+	assert.Equal(t, []string{"cli/cli"}, original.Repo)
+	assert.True(t, *original.Archived)
+	assert.Equal(t, "open", original.State)
+}
+
+func TestQualifiersMergeWith(t *testing.T) {
+	trueBool := true
+	base := Qualifiers{
+		Label: []string{"bug", "p1"},
+		Repo:  []string{"cli/cli"},
+		State: "open",
+		Type:  "issue",
+	}
+	other := Qualifiers{
+		Archived: &trueBool,
+		Label:    []string{"p1", "regression"},
+		Repo:     []string{"cli/go-gh", "cli/cli"},
+		State:    "closed",
+	}
+
+	merged := base.MergeWith(other)
+
+	// Slices union with duplicates removed, keeping first-seen order.
+	assert.Equal(t, []string{"bug", "p1", "regression"}, merged.Label)
+	assert.Equal(t, []string{"cli/cli", "cli/go-gh"}, merged.Repo)
+	// Non-zero scalars from other win; zero ones keep the base value.
+	assert.Equal(t, "closed", merged.State)
+	assert.Equal(t, "issue", merged.Type)
+	assert.True(t, *merged.Archived)
+
+	// Neither input is modified.
+	assert.Equal(t, []string{"bug", "p1"}, base.Label)
+	assert.Equal(t, "open", base.State)
+	assert.Nil(t, base.Archived)
+	assert.Equal(t, []string{"p1", "regression"}, other.Label)
+}
+
 func TestCamelToKebab(t *testing.T) {
 	tests := []struct {
 		name string
@@ -311,3 +553,631 @@ func TestCamelToKebab(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryDescribe(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		out   string
+	}{
+		{
+			name: "describes a representative query",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					State:   "open",
+					Repo:    []string{"cli/cli"},
+					Label:   []string{"bug"},
+					Created: ">2023-01-01",
+				},
+			},
+			out: "open issues in cli/cli labeled 'bug' created after 2023-01-01",
+		},
+		{
+			name: "describes keywords and authorship",
+			query: Query{
+				Kind:     KindIssues,
+				Keywords: []string{"panic"},
+				Qualifiers: Qualifiers{
+					Author:   "octocat",
+					Assignee: "hubot",
+				},
+			},
+			out: `issues matching "panic" authored by octocat assigned to hubot`,
+		},
+		{
+			name: "describes a date range",
+			query: Query{
+				Kind: KindRepositories,
+				Qualifiers: Qualifiers{
+					Created:  "2023-01-01..2023-12-31",
+					Language: "go",
+				},
+			},
+			out: "repositories written in go created between 2023-01-01 and 2023-12-31",
+		},
+		{
+			name: "falls back to key: value phrasing",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Milestone: "v1.0",
+					Is:        []string{"pr"},
+				},
+			},
+			out: "issues is: pr milestone: v1.0",
+		},
+		{
+			name:  "empty query",
+			query: Query{},
+			out:   "results",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, tt.query.Describe())
+		})
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   Query
+		wantErr string
+	}{
+		{
+			name: "valid issues query",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					In:     []string{"title", "body"},
+					Is:     []string{"open", "pr"},
+					State:  "open",
+					Review: "approved",
+					Type:   "pr",
+				},
+			},
+		},
+		{
+			name: "valid repositories query",
+			query: Query{
+				Kind: KindRepositories,
+				Qualifiers: Qualifiers{
+					In: []string{"name", "description"},
+					Is: []string{"public"},
+				},
+			},
+		},
+		{
+			name: "issues query built from Is constants",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Is: []string{IsOpen, IsDraft, IsMerged, IsPrivate},
+				},
+			},
+		},
+		{
+			name: "invalid is value for issues",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Is: []string{"opened"},
+				},
+			},
+			wantErr: `invalid value "opened" for "is" qualifier in issues search (must be one of: blocked, blocking, closed, draft, issue, locked, merged, open, pr, private, public, queued, unlocked, unmerged)`,
+		},
+		{
+			name: "invalid in value for issues",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					In: []string{"readme"},
+				},
+			},
+			wantErr: `invalid value "readme" for "in" qualifier in issues search (must be one of: body, comments, title)`,
+		},
+		{
+			name: "invalid is value for repositories",
+			query: Query{
+				Kind: KindRepositories,
+				Qualifiers: Qualifiers{
+					Is: []string{"merged"},
+				},
+			},
+			wantErr: `invalid value "merged" for "is" qualifier in repositories search (must be one of: archived, fork, internal, mirror, private, public, sponsorable, template)`,
+		},
+		{
+			name: "invalid state value",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					State: "merged",
+				},
+			},
+			wantErr: `invalid value "merged" for "state" qualifier in issues search (must be one of: closed, open)`,
+		},
+		{
+			name: "review built from Review constants",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Review: ReviewChangesRequested,
+				},
+			},
+		},
+		{
+			name: "review-requested and reviewed-by accept logins",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Review:          ReviewApproved,
+					ReviewRequested: "octocat",
+					ReviewedBy:      "cli/core",
+				},
+			},
+		},
+		{
+			name: "invalid review value",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Review: "pending",
+				},
+			},
+			wantErr: `invalid value "pending" for "review" qualifier in issues search (must be one of: approved, changes_requested, none, required)`,
+		},
+		{
+			name: "invalid type value",
+			query: Query{
+				Kind: KindIssues,
+				Qualifiers: Qualifiers{
+					Type: "discussion",
+				},
+			},
+			wantErr: `invalid value "discussion" for "type" qualifier in issues search (must be one of: issue, pr)`,
+		},
+		{
+			name: "unvalidated kind is skipped",
+			query: Query{
+				Kind: KindCommits,
+				Qualifiers: Qualifiers{
+					In: []string{"anything"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	trueBool := true
+	tests := []struct {
+		name    string
+		query   string
+		out     Query
+		wantErr string
+	}{
+		{
+			name:  "empty query",
+			query: "",
+			out:   Query{},
+		},
+		{
+			name:  "keywords only",
+			query: "some keywords",
+			out:   Query{Keywords: []string{"some", "keywords"}},
+		},
+		{
+			name:  "qualifiers",
+			query: "state:open repo:cli/cli label:bug label:question",
+			out: Query{
+				Qualifiers: Qualifiers{
+					State: "open",
+					Repo:  []string{"cli/cli"},
+					Label: []string{"bug", "question"},
+				},
+			},
+		},
+		{
+			name:  "quoted value",
+			query: `label:"help wanted" keyword`,
+			out: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Label: []string{"help wanted"},
+				},
+			},
+		},
+		{
+			name:  "quoted keyword",
+			query: `"exact phrase" state:closed`,
+			out: Query{
+				Keywords: []string{"exact phrase"},
+				Qualifiers: Qualifiers{
+					State: "closed",
+				},
+			},
+		},
+		{
+			name:  "boolean qualifier",
+			query: "archived:true",
+			out: Query{
+				Qualifiers: Qualifiers{
+					Archived: &trueBool,
+				},
+			},
+		},
+		{
+			name:  "kebab-case qualifier",
+			query: "good-first-issues:>10",
+			out: Query{
+				Qualifiers: Qualifiers{
+					GoodFirstIssues: ">10",
+				},
+			},
+		},
+		{
+			name:  "unknown qualifier becomes keyword",
+			query: "unknown:value",
+			out: Query{
+				Keywords: []string{"unknown:value"},
+			},
+		},
+		{
+			name:    "unterminated quote",
+			query:   `label:"help wanted`,
+			wantErr: `unterminated quote in query: label:"help wanted`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.query)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.out, got)
+		})
+	}
+}
+
+func TestParseQueryRoundTrip(t *testing.T) {
+	query := Query{
+		Keywords: []string{"progress"},
+		Qualifiers: Qualifiers{
+			Label: []string{"bug", "help wanted"},
+			Repo:  []string{"cli/cli"},
+			State: "open",
+		},
+	}
+
+	parsed, err := ParseQuery(query.StandardSearchString())
+	assert.NoError(t, err)
+	assert.Equal(t, query.Keywords, parsed.Keywords)
+	// Rendering sorts repeated qualifiers, so compare values without order.
+	assert.ElementsMatch(t, query.Qualifiers.Label, parsed.Qualifiers.Label)
+	assert.Equal(t, query.Qualifiers.Repo, parsed.Qualifiers.Repo)
+	assert.Equal(t, query.Qualifiers.State, parsed.Qualifiers.State)
+}
+
+func TestQueryNotQualifiers(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        Query
+		wantStandard string
+		wantAdvanced string
+	}{
+		{
+			name: "negated qualifiers",
+			query: Query{
+				Keywords: []string{"progress"},
+				Qualifiers: Qualifiers{
+					Label: []string{"bug"},
+				},
+				NotQualifiers: Qualifiers{
+					Author: "octocat",
+					Label:  []string{"wontfix"},
+				},
+			},
+			wantStandard: "progress label:bug -author:octocat -label:wontfix",
+			wantAdvanced: "progress label:bug -author:octocat -label:wontfix",
+		},
+		{
+			name: "negated special qualifiers are not OR-grouped",
+			query: Query{
+				NotQualifiers: Qualifiers{
+					Is:   []string{"draft"},
+					Repo: []string{"cli/cli", "cli/go-gh"},
+				},
+			},
+			wantStandard: "-is:draft -repo:cli/cli -repo:cli/go-gh",
+			wantAdvanced: "-is:draft -repo:cli/cli -repo:cli/go-gh",
+		},
+		{
+			name: "negated quoted value",
+			query: Query{
+				NotQualifiers: Qualifiers{
+					Label: []string{"help wanted"},
+				},
+			},
+			wantStandard: `-label:"help wanted"`,
+			wantAdvanced: `-label:"help wanted"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStandard, tt.query.StandardSearchString())
+			assert.Equal(t, tt.wantAdvanced, tt.query.AdvancedIssueSearchString())
+		})
+	}
+}
+
+func TestDateRangeHelpers(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return d
+	}
+
+	from := date("2023-01-01T00:00:00Z")
+	to := date("2023-12-31T00:00:00Z")
+	timestamp := date("2023-06-01T12:30:00Z")
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "closed range", got: DateRange(from, to), want: "2023-01-01..2023-12-31"},
+		{name: "open-ended from", got: DateRange(time.Time{}, to), want: "<=2023-12-31"},
+		{name: "open-ended to", got: DateRange(from, time.Time{}), want: ">=2023-01-01"},
+		{name: "empty range", got: DateRange(time.Time{}, time.Time{}), want: ""},
+		{name: "after", got: After(from), want: ">2023-01-01"},
+		{name: "before", got: Before(to), want: "<2023-12-31"},
+		{name: "on or after", got: OnOrAfter(from), want: ">=2023-01-01"},
+		{name: "on or before", got: OnOrBefore(to), want: "<=2023-12-31"},
+		{name: "timestamp keeps time component", got: After(timestamp), want: ">2023-06-01T12:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got)
+		})
+	}
+}
+
+func TestStandardSearchStringPreservingOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		out   string
+	}{
+		{
+			name: "keeps multi-value qualifiers in insertion order",
+			query: Query{
+				Keywords: []string{"keyword"},
+				Qualifiers: Qualifiers{
+					Label: []string{"zebra", "alpha"},
+					Repo:  []string{"cli/cli"},
+				},
+			},
+			out: "keyword label:zebra label:alpha repo:cli/cli",
+		},
+		{
+			name: "qualifiers render in field order",
+			query: Query{
+				Qualifiers: Qualifiers{
+					State:  "open",
+					Author: "octocat",
+				},
+			},
+			out: "author:octocat state:open",
+		},
+		{
+			name: "negated qualifiers keep order too",
+			query: Query{
+				Qualifiers: Qualifiers{
+					Label: []string{"bug"},
+				},
+				NotQualifiers: Qualifiers{
+					Label: []string{"wontfix", "duplicate"},
+				},
+			},
+			out: "label:bug -label:wontfix -label:duplicate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, tt.query.StandardSearchStringPreservingOrder())
+		})
+	}
+}
+
+func TestQueryMatches(t *testing.T) {
+	boolP := func(b bool) *bool { return &b }
+	tests := []struct {
+		name    string
+		query   Query
+		attrs   map[string]string
+		matches bool
+	}{
+		{
+			name:    "matches string qualifiers",
+			query:   Query{Qualifiers: Qualifiers{State: "open", Author: "octocat"}},
+			attrs:   map[string]string{"state": "open", "author": "octocat"},
+			matches: true,
+		},
+		{
+			name:    "mismatched string qualifier",
+			query:   Query{Qualifiers: Qualifiers{State: "open"}},
+			attrs:   map[string]string{"state": "closed"},
+			matches: false,
+		},
+		{
+			name:    "string comparison is case-insensitive",
+			query:   Query{Qualifiers: Qualifiers{Author: "Octocat"}},
+			attrs:   map[string]string{"author": "octocat"},
+			matches: true,
+		},
+		{
+			name:    "matches boolean qualifiers",
+			query:   Query{Qualifiers: Qualifiers{Archived: boolP(false), Draft: boolP(true)}},
+			attrs:   map[string]string{"archived": "false", "draft": "true"},
+			matches: true,
+		},
+		{
+			name:    "mismatched boolean qualifier",
+			query:   Query{Qualifiers: Qualifiers{Draft: boolP(true)}},
+			attrs:   map[string]string{"draft": "false"},
+			matches: false,
+		},
+		{
+			name:    "missing attribute does not match",
+			query:   Query{Qualifiers: Qualifiers{Milestone: "v1"}},
+			attrs:   map[string]string{"state": "open"},
+			matches: false,
+		},
+		{
+			name:    "ignores multi-value qualifiers",
+			query:   Query{Qualifiers: Qualifiers{Label: []string{"bug"}, State: "open"}},
+			attrs:   map[string]string{"state": "open"},
+			matches: true,
+		},
+		{
+			name:    "ignores range expressions",
+			query:   Query{Qualifiers: Qualifiers{Created: ">2022-01-01", State: "open"}},
+			attrs:   map[string]string{"state": "open"},
+			matches: true,
+		},
+		{
+			name:    "no qualifiers matches everything",
+			query:   Query{},
+			attrs:   map[string]string{"state": "open"},
+			matches: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.query.Matches(tt.attrs))
+		})
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return d
+	}
+	tests := []struct {
+		name    string
+		value   string
+		time    time.Time
+		matches bool
+		wantErr string
+	}{
+		{
+			name:    "exact date matches within the day",
+			value:   "2024-01-15",
+			time:    date("2024-01-15T23:59:59Z"),
+			matches: true,
+		},
+		{
+			name:    "exact date excludes the next day",
+			value:   "2024-01-15",
+			time:    date("2024-01-16T00:00:00Z"),
+			matches: false,
+		},
+		{
+			name:    "after excludes the named day",
+			value:   ">2024-01-15",
+			time:    date("2024-01-15T23:59:59Z"),
+			matches: false,
+		},
+		{
+			name:    "after matches the next day",
+			value:   ">2024-01-15",
+			time:    date("2024-01-16T00:00:00Z"),
+			matches: true,
+		},
+		{
+			name:    "on or after includes the named day",
+			value:   ">=2024-01-15",
+			time:    date("2024-01-15T00:00:00Z"),
+			matches: true,
+		},
+		{
+			name:    "before excludes the named day",
+			value:   "<2024-01-15",
+			time:    date("2024-01-15T00:00:00Z"),
+			matches: false,
+		},
+		{
+			name:    "on or before includes the named day",
+			value:   "<=2024-01-15",
+			time:    date("2024-01-15T23:59:59Z"),
+			matches: true,
+		},
+		{
+			name:    "range includes both endpoints",
+			value:   "2024-01-01..2024-02-01",
+			time:    date("2024-02-01T12:00:00Z"),
+			matches: true,
+		},
+		{
+			name:    "range excludes dates outside",
+			value:   "2024-01-01..2024-02-01",
+			time:    date("2024-02-02T00:00:00Z"),
+			matches: false,
+		},
+		{
+			name:    "open-ended range",
+			value:   "*..2024-02-01",
+			time:    date("1999-06-01T00:00:00Z"),
+			matches: true,
+		},
+		{
+			name:    "timestamp bound",
+			value:   ">=2024-01-15T10:30:00Z",
+			time:    date("2024-01-15T10:29:59Z"),
+			matches: false,
+		},
+		{
+			name:    "invalid date",
+			value:   ">soon",
+			wantErr: `invalid date "soon" (expected YYYY-MM-DD or RFC3339)`,
+		},
+		{
+			name:    "invalid range bound",
+			value:   "2024-01-01..whenever",
+			wantErr: `invalid date "whenever" (expected YYYY-MM-DD or RFC3339)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := ParseDateRange(tt.value)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.matches, matcher(tt.time))
+		})
+	}
+}